@@ -26,14 +26,21 @@ cd ..
 cd cmd
 go test -v
 
-# run create database and run migration
-export MYSQL_PWD=$DB_PASS;
-mysql -u $DB_USER \
--e "CREATE DATABASE IF NOT EXISTS $DB_NAME;";
-
-# migrate table question
-mysql -u $DB_USER $DB_NAME < ../database/migration.sql
+# create the mysql database, postgres expects it to exist already and
+# sqlite creates its file on first run
+cd ..
+case "$DB_DRIVER" in
+postgres|postgresql|sqlite|sqlite3)
+  ;;
+*)
+  export MYSQL_PWD=$DB_PASS;
+  mysql -u $DB_USER \
+  -e "CREATE DATABASE IF NOT EXISTS $DB_NAME;";
+  ;;
+esac
 
 # build app
-cd ..
-go build -o bin/quiz_master
\ No newline at end of file
+go build -o bin/quiz_master
+
+# migrate the schema
+./bin/quiz_master migrate up
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"quiz_master/domain"
+	"time"
 )
 
 type Option func(*domain.Question)
@@ -31,3 +32,51 @@ func SetAnswer(answer string) Option {
 		q.Answer = answer
 	}
 }
+
+func SetAnswerType(answerType string) Option {
+	return func(q *domain.Question) {
+		q.AnswerType = answerType
+	}
+}
+
+func SetLang(lang string) Option {
+	return func(q *domain.Question) {
+		q.Lang = lang
+	}
+}
+
+func SetGrading(grading domain.Grading) Option {
+	return func(q *domain.Question) {
+		q.Grading = grading
+	}
+}
+
+func SetFuzzy(fuzzy int) Option {
+	return func(q *domain.Question) {
+		q.Fuzzy = fuzzy
+	}
+}
+
+func SetTimeLimit(limit time.Duration) Option {
+	return func(q *domain.Question) {
+		q.TimeLimit = limit
+	}
+}
+
+func SetHints(hints ...string) Option {
+	return func(q *domain.Question) {
+		q.Hints = hints
+	}
+}
+
+func SetExplanation(explanation string) Option {
+	return func(q *domain.Question) {
+		q.Explanation = explanation
+	}
+}
+
+func SetChoices(choices []domain.Choice) Option {
+	return func(q *domain.Question) {
+		q.Choices = choices
+	}
+}
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"quiz_master/dto"
+	"time"
 )
 
 type OptionRequestGetOrDelete func(*dto.RequestGetOrDeleteQuestion)
@@ -19,3 +20,73 @@ func GetOrDeleteWithNumber(number string) OptionRequestGetOrDelete {
 		r.Number = number
 	}
 }
+
+type OptionRequestUpdate func(*dto.RequestUpdateQuestion)
+
+func NewRequestUpdate(options ...OptionRequestUpdate) *dto.RequestUpdateQuestion {
+	r := &dto.RequestUpdateQuestion{}
+	for _, o := range options {
+		o(r)
+	}
+	return r
+}
+
+func UpdateWithNumber(number string) OptionRequestUpdate {
+	return func(r *dto.RequestUpdateQuestion) {
+		r.Number = &number
+	}
+}
+
+func UpdateWithQuestion(question string) OptionRequestUpdate {
+	return func(r *dto.RequestUpdateQuestion) {
+		r.Question = &question
+	}
+}
+
+func UpdateWithAnswer(answer string) OptionRequestUpdate {
+	return func(r *dto.RequestUpdateQuestion) {
+		r.Answer = &answer
+	}
+}
+
+func UpdateWithAnswerType(answerType string) OptionRequestUpdate {
+	return func(r *dto.RequestUpdateQuestion) {
+		r.AnswerType = &answerType
+	}
+}
+
+func UpdateWithLang(lang string) OptionRequestUpdate {
+	return func(r *dto.RequestUpdateQuestion) {
+		r.Lang = &lang
+	}
+}
+
+func UpdateWithGrading(grading dto.RequestGrading) OptionRequestUpdate {
+	return func(r *dto.RequestUpdateQuestion) {
+		r.Grading = &grading
+	}
+}
+
+func UpdateWithFuzzy(fuzzy int) OptionRequestUpdate {
+	return func(r *dto.RequestUpdateQuestion) {
+		r.Fuzzy = &fuzzy
+	}
+}
+
+func UpdateWithTimeLimit(limit time.Duration) OptionRequestUpdate {
+	return func(r *dto.RequestUpdateQuestion) {
+		r.TimeLimit = &limit
+	}
+}
+
+func UpdateWithHints(hints []string) OptionRequestUpdate {
+	return func(r *dto.RequestUpdateQuestion) {
+		r.Hints = &hints
+	}
+}
+
+func UpdateWithExplanation(explanation string) OptionRequestUpdate {
+	return func(r *dto.RequestUpdateQuestion) {
+		r.Explanation = &explanation
+	}
+}
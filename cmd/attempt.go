@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"os/user"
+	"quiz_master/database"
+	"quiz_master/domain"
+	"quiz_master/helper"
+	"quiz_master/repository"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newAttemptRepository(driver string, db *sql.DB) domain.AttemptRepository {
+	switch driver {
+	case database.DriverSQLite:
+		return repository.NewSQLiteAttemptRepository(db)
+	case database.DriverPostgres:
+		return repository.NewPostgresAttemptRepository(db)
+	default:
+		return repository.NewAttemptRepository(db)
+	}
+}
+
+// currentPlayer is who answers: the --player flag, else the player setting,
+// else the user logged in.
+func currentPlayer(player string) string {
+	if player != "" {
+		return player
+	}
+	if player = viper.GetString("player"); player != "" {
+		return player
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
+
+func NewHistoryCmd(a domain.AttemptUsecase) *cobra.Command {
+	var filter domain.AttemptFilter
+	historyCmd := &cobra.Command{
+		Use:   "history [--player X] [--question N]",
+		Short: "This command is use to list the answers given so far",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			attempts, err := a.History(filter)
+			if err != nil {
+				return err
+			}
+			out.Render(helper.HistoryView(attempts))
+			return nil
+		},
+	}
+	historyCmd.Flags().StringVar(&filter.Player, "player", "", "only the answers of this player")
+	historyCmd.Flags().StringVar(&filter.QuestionNumber, "question", "", "only the answers to this question number")
+	return historyCmd
+}
+
+func NewScoreCmd(a domain.AttemptUsecase) *cobra.Command {
+	var player string
+	scoreCmd := &cobra.Command{
+		Use:   "score [--player X]",
+		Short: "This command is use to show the score of a player",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			score, err := a.Score(currentPlayer(player))
+			if err != nil {
+				return err
+			}
+			out.Render(helper.ScoreView(score))
+			return nil
+		},
+	}
+	scoreCmd.Flags().StringVar(&player, "player", "", "player to score, defaults to the player setting or the user logged in")
+	return scoreCmd
+}
+
+// recordAttempt stores an answer in the history. Grading already happened,
+// so a failure is reported without failing the command.
+func recordAttempt(out helper.Renderer, a domain.AttemptUsecase, attempt *domain.Attempt) {
+	if err := a.Record(attempt); err != nil {
+		reportNotRecorded(out, err)
+	}
+}
+
+func reportNotRecorded(out helper.Renderer, err error) {
+	out.Error(fmt.Errorf("Attempt not recorded: %w", err))
+}
+
+func NewLeaderboardCmd(a domain.AttemptUsecase) *cobra.Command {
+	var since, by string
+	var limit int
+	leaderboardCmd := &cobra.Command{
+		Use:   "leaderboard [--since 7d] [--limit 20] [--by accuracy|points|streak]",
+		Short: "This command is use to rank the players",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			window, err := helper.ParseDuration(since)
+			if err != nil {
+				return domain.Invalid("since", "%s", err)
+			}
+
+			entries, err := a.Leaderboard(by, window, limit)
+			if err != nil {
+				return err
+			}
+			out.Render(helper.LeaderboardView(entries))
+			return nil
+		},
+	}
+	leaderboardCmd.Flags().StringVar(&since, "since", "", "only count answers given this long ago or later, e.g. 7d")
+	leaderboardCmd.Flags().IntVar(&limit, "limit", 20, "number of players to show")
+	leaderboardCmd.Flags().StringVar(&by, "by", domain.LeaderboardByPoints, "rank by accuracy, points or streak")
+	return leaderboardCmd
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"quiz_master/domain"
+	"quiz_master/domain/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// acceptAttempts records every attempt without looking at it.
+func acceptAttempts() *mocks.AttemptUsecase {
+	m := new(mocks.AttemptUsecase)
+	m.On("Record", mock.Anything).Return(nil)
+	return m
+}
+
+func TestAnswerQuestion_RecordsAttempt(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("AnswerQuestion", "1", "3").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeIncorrect, Feedback: "Wrong Answer!"}, nil).Once()
+	mockAttemptUsecase := new(mocks.AttemptUsecase)
+	mockAttemptUsecase.On("Record", &domain.Attempt{Player: "alice", QuestionNumber: "1", Answer: "3"}).
+		Return(fmt.Errorf("database is locked")).Once()
+
+	cmd := NewAnswerQuestionCmd(mockQuestionUsecase, mockAttemptUsecase)
+	b, e := bytes.NewBufferString(""), bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(e)
+	cmd.SetArgs([]string{"1", "3", "--player", "alice"})
+	assert.Equal(t, ExitWrongAnswer, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Wrong Answer!\n")
+	assert.Equal(t, e.String(), "Attempt not recorded: database is locked\n")
+	mockAttemptUsecase.AssertExpectations(t)
+}
+
+func TestHistory_Success(t *testing.T) {
+	mockAttemptUsecase := new(mocks.AttemptUsecase)
+	answeredAt := time.Date(2022, 3, 1, 10, 0, 0, 0, time.Local)
+	mockAttemptUsecase.On("History", domain.AttemptFilter{Player: "alice", QuestionNumber: "2"}).Return([]*domain.Attempt{
+		{Player: "alice", QuestionNumber: "2", Answer: "Rome", AnsweredAt: answeredAt, Duration: 1500 * time.Millisecond},
+		{Player: "alice", QuestionNumber: "2", Answer: "Paris", Correct: true, AnsweredAt: answeredAt.Add(time.Minute)},
+	}, nil).Once()
+
+	cmd := NewHistoryCmd(mockAttemptUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--player", "alice", "--question", "2"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Answered at\t\tPlayer\tNo\tAnswer\tResult\tTime\n"+
+		"2022-03-01 10:00:00\talice\t2\t\"Rome\"\twrong\t1.5s\n"+
+		"2022-03-01 10:01:00\talice\t2\t\"Paris\"\tcorrect\t0s\n\n")
+	mockAttemptUsecase.AssertExpectations(t)
+}
+
+func TestScore_Success(t *testing.T) {
+	mockAttemptUsecase := new(mocks.AttemptUsecase)
+	mockAttemptUsecase.On("Score", "alice").Return(domain.PlayerScore{
+		Player: "alice", Attempts: 3, Correct: 1, Score: 1.5,
+		Questions: []domain.QuestionScore{
+			{QuestionNumber: "1", Attempts: 1, Correct: 1, Score: 1},
+			{QuestionNumber: "2", Attempts: 2, Correct: 0, Score: 0.5},
+		},
+	}, nil).Once()
+
+	cmd := NewScoreCmd(mockAttemptUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--player", "alice"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Player : alice\nAttempts : 3\nCorrect : 1 (33%)\nScore : 1.5\n\n"+
+		"No |\tAttempts\t|\tCorrect\n1\t1\t\t\t1\n2\t2\t\t\t0\n")
+	mockAttemptUsecase.AssertExpectations(t)
+}
+
+func TestLeaderboard_Success(t *testing.T) {
+	entries := []domain.LeaderboardEntry{
+		{Rank: 1, Player: "alice", Attempts: 4, Correct: 3, Accuracy: 0.75, Points: 3, Streak: 2},
+		{Rank: 2, Player: "bob", Attempts: 2, Correct: 1, Accuracy: 0.5, Points: 1.5, Streak: 1},
+	}
+	mockAttemptUsecase := new(mocks.AttemptUsecase)
+	mockAttemptUsecase.On("Leaderboard", domain.LeaderboardByPoints, 7*24*time.Hour, 20).Return(entries, nil).Once()
+
+	cmd := NewLeaderboardCmd(mockAttemptUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--since", "7d"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Rank |\tPlayer\t|\tPoints\t|\tAccuracy\t|\tStreak\t|\tAttempts\n"+
+		"1\talice\t\t3\t\t75%\t\t\t2\t\t4\n"+
+		"2\tbob\t\t1.5\t\t50%\t\t\t1\t\t2\n\n")
+	mockAttemptUsecase.AssertExpectations(t)
+}
+
+func TestLeaderboard_JSON(t *testing.T) {
+	mockAttemptUsecase := new(mocks.AttemptUsecase)
+	mockAttemptUsecase.On("Leaderboard", domain.LeaderboardByAccuracy, time.Duration(0), 1).Return([]domain.LeaderboardEntry{
+		{Rank: 1, Player: "alice", Attempts: 4, Correct: 3, Accuracy: 0.75, Points: 3, Streak: 2},
+	}, nil).Once()
+
+	out, _ := executeWithOutput(NewLeaderboardCmd(mockAttemptUsecase), "--by", "accuracy", "--limit", "1", "-o", "json")
+	assert.JSONEq(t, `[{"rank":1,"player":"alice","attempts":4,"correct":3,"accuracy":0.75,"points":3,"streak":2}]`, out)
+	mockAttemptUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"quiz_master/domain"
+	"quiz_master/helper"
+
+	"github.com/spf13/cobra"
+)
+
+func NewHintCmd(u domain.QuestionUsecase, a domain.AttemptUsecase) *cobra.Command {
+	var player string
+	hintCmd := &cobra.Command{
+		Use:   "hint <number> [--player X]",
+		Short: "This command is use to reveal the next hint of a question",
+		Long: `This command is use to reveal the next hint of a question.
+
+Each hint shown counts against the next answer of the player to the question,
+taking hint_penalty off its score.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			question, err := u.GetByNumber(args[0])
+			if err != nil {
+				return err
+			}
+
+			use, err := a.UseHint(currentPlayer(player), question)
+			if err != nil {
+				return err
+			}
+			out.Render(helper.HintView(use))
+			return nil
+		},
+	}
+	hintCmd.Flags().StringVar(&player, "player", "", "who asks, defaults to the player setting or the user logged in")
+	return hintCmd
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"quiz_master/domain"
+	"quiz_master/domain/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHint_Success(t *testing.T) {
+	question := domain.Question{Number: "1", Hints: []string{"It's in Europe", "It starts with P"}}
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("GetByNumber", "1").Return(question, nil).Once()
+	mockAttemptUsecase := new(mocks.AttemptUsecase)
+	mockAttemptUsecase.On("UseHint", "bob", question).
+		Return(domain.HintUse{Player: "bob", QuestionNumber: "1", Hint: 1, Total: 2, Content: "It's in Europe"}, nil).Once()
+
+	cmd := NewHintCmd(mockQuestionUsecase, mockAttemptUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "--player", "bob"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Hint 1/2 : It's in Europe\n")
+	mockQuestionUsecase.AssertExpectations(t)
+	mockAttemptUsecase.AssertExpectations(t)
+}
+
+func TestHint_Fail(t *testing.T) {
+	question := domain.Question{Number: "1"}
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("GetByNumber", "1").Return(question, nil).Once()
+	mockAttemptUsecase := new(mocks.AttemptUsecase)
+	mockAttemptUsecase.On("UseHint", "bob", question).
+		Return(domain.HintUse{}, fmt.Errorf("Question no 1 has no hints")).Once()
+
+	cmd := NewHintCmd(mockQuestionUsecase, mockAttemptUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"1", "--player", "bob"})
+	assert.Equal(t, ExitGeneric, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 has no hints\n")
+	mockAttemptUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"quiz_master/database"
+	"quiz_master/domain"
+	"quiz_master/helper"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// skipSchemaCheck marks commands that may run while migrations are pending.
+const skipSchemaCheck = "skipSchemaCheck"
+
+func NewMigrateCmd(m *database.Migrator) *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use:         "migrate",
+		Short:       "This command is use to manage the database schema",
+		Annotations: map[string]string{skipSchemaCheck: "true"},
+	}
+	migrateCmd.AddCommand(newMigrateUpCmd(m))
+	migrateCmd.AddCommand(newMigrateDownCmd(m))
+	migrateCmd.AddCommand(newMigrateStatusCmd(m))
+	migrateCmd.AddCommand(newMigrateCreateCmd())
+	return migrateCmd
+}
+
+func newMigrateUpCmd(m *database.Migrator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "up",
+		Short: "This command is use to apply every pending migration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			applied, err := m.Up()
+			if len(applied) > 0 || err == nil {
+				out.Render(migrationsView("Applied", "Nothing to migrate", applied))
+			}
+			return err
+		},
+	}
+}
+
+func newMigrateDownCmd(m *database.Migrator) *cobra.Command {
+	var steps int
+	downCmd := &cobra.Command{
+		Use:   "down",
+		Short: "This command is use to roll back the latest migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			rolledBack, err := m.Down(steps)
+			if len(rolledBack) > 0 || err == nil {
+				out.Render(migrationsView("Rolled back", "Nothing to roll back", rolledBack))
+			}
+			return err
+		},
+	}
+	downCmd.Flags().IntVar(&steps, "steps", 1, "number of migrations to roll back")
+	return downCmd
+}
+
+func newMigrateStatusCmd(m *database.Migrator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "This command is use to show which migrations are applied",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			statuses, err := m.Status()
+			if err != nil {
+				return err
+			}
+			out.Render(migrationStatusView(statuses))
+			return nil
+		},
+	}
+}
+
+func newMigrateCreateCmd() *cobra.Command {
+	var dir string
+	createCmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "This command is use to create a new migration for every driver",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			files, err := database.CreateMigration(dir, args[0])
+			if len(files) > 0 || err == nil {
+				out.Render(createdFilesView(files))
+			}
+			return err
+		},
+	}
+	createCmd.Flags().StringVar(&dir, "dir", "database/migrations", "directory holding the migrations of every driver")
+	return createCmd
+}
+
+// migrationOutput is a migration as rendered, without its SQL.
+type migrationOutput struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+// migrationsView shows the migrations a command ran, each line starting
+// with done, or none when there are none.
+func migrationsView(done, none string, migrations []database.Migration) helper.View {
+	statuses := make([]database.MigrationStatus, len(migrations))
+	for i, mig := range migrations {
+		statuses[i] = database.MigrationStatus{Migration: mig, Applied: done == "Applied"}
+	}
+	view := migrationStatusView(statuses)
+	view.Table = func(w io.Writer) {
+		for _, mig := range migrations {
+			fmt.Fprintf(w, "%s %04d_%s\n", done, mig.Version, mig.Name)
+		}
+		if len(migrations) == 0 {
+			fmt.Fprintln(w, none)
+		}
+	}
+	return view
+}
+
+func migrationStatusView(statuses []database.MigrationStatus) helper.View {
+	data := make([]migrationOutput, len(statuses))
+	rows := make([][]string, len(statuses))
+	for i, s := range statuses {
+		data[i] = migrationOutput{Version: s.Version, Name: s.Name, Applied: s.Applied}
+		appliedAt := ""
+		if s.Applied && !s.AppliedAt.IsZero() {
+			at := s.AppliedAt
+			data[i].AppliedAt = &at
+			appliedAt = at.UTC().Format(time.RFC3339)
+		}
+		rows[i] = []string{strconv.FormatInt(s.Version, 10), s.Name, strconv.FormatBool(s.Applied), appliedAt}
+	}
+	return helper.View{
+		Data:   data,
+		Header: []string{"version", "name", "applied", "applied_at"},
+		Rows:   rows,
+		Table: func(w io.Writer) {
+			for _, s := range statuses {
+				state := "pending"
+				if s.Applied {
+					state = "applied at " + s.AppliedAt.Format("2006-01-02 15:04:05")
+				}
+				fmt.Fprintf(w, "%04d_%s\t%s\n", s.Version, s.Name, state)
+			}
+		},
+	}
+}
+
+func createdFilesView(files []string) helper.View {
+	rows := make([][]string, len(files))
+	for i, f := range files {
+		rows[i] = []string{f}
+	}
+	return helper.View{
+		Data:   map[string][]string{"created": files},
+		Header: []string{"file"},
+		Rows:   rows,
+		Table: func(w io.Writer) {
+			for _, f := range files {
+				fmt.Fprintf(w, "Created %s\n", f)
+			}
+		},
+	}
+}
+
+// checkSchema refuses to run commands against a database with pending
+// migrations. With autoMigrate, used for the embedded SQLite database, they
+// are applied on the spot instead.
+func checkSchema(m *database.Migrator, autoMigrate bool) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if !needsSchema(cmd) {
+			return nil
+		}
+
+		cmd.SilenceUsage = true
+		if autoMigrate {
+			if _, err := m.Up(); err != nil {
+				return domain.NewError(domain.ErrUnavailable, err)
+			}
+			return nil
+		}
+
+		pending, err := m.Pending()
+		if err != nil {
+			return domain.NewError(domain.ErrUnavailable, err)
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("database schema is out of date, %d migration(s) pending; run `quiz_master migrate up`", len(pending))
+		}
+		return nil
+	}
+}
+
+func needsSchema(cmd *cobra.Command) bool {
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		if c.Annotations[skipSchemaCheck] == "true" {
+			return false
+		}
+		switch c.Name() {
+		case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return false
+		}
+	}
+	return true
+}
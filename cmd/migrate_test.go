@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"database/sql"
+	"io/ioutil"
+	"quiz_master/database"
+	"quiz_master/domain"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMigrator(t *testing.T) *database.Migrator {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := database.NewMigrator(db, database.DriverSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMigrate_UpAndStatus(t *testing.T) {
+	m := newTestMigrator(t)
+
+	cmd := NewMigrateCmd(m)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"up"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, string(out), "Applied 0001_create_questions\n")
+
+	cmd.SetArgs([]string{"up"})
+	run(cmd)
+	out, _ = ioutil.ReadAll(b)
+	assert.Equal(t, "Nothing to migrate\n", string(out))
+
+	cmd.SetArgs([]string{"status"})
+	run(cmd)
+	out, _ = ioutil.ReadAll(b)
+	assert.Contains(t, string(out), "0001_create_questions\tapplied at ")
+}
+
+func TestCheckSchema_RefusesPendingMigrations(t *testing.T) {
+	m := newTestMigrator(t)
+	root := &cobra.Command{Use: "quiz_master"}
+	child := &cobra.Command{Use: "list_question", Run: func(cmd *cobra.Command, args []string) {}}
+	root.AddCommand(child)
+	root.AddCommand(NewMigrateCmd(m))
+
+	err := checkSchema(m, false)(child, nil)
+	assert.Error(t, err)
+
+	status, _, _ := root.Find([]string{"migrate", "status"})
+	assert.NoError(t, checkSchema(m, false)(status, nil))
+}
+
+func TestCheckSchema_AutoMigrate(t *testing.T) {
+	m := newTestMigrator(t)
+	root := &cobra.Command{Use: "quiz_master"}
+	child := &cobra.Command{Use: "list_question", Run: func(cmd *cobra.Command, args []string) {}}
+	root.AddCommand(child)
+
+	assert.NoError(t, checkSchema(m, true)(child, nil))
+	pending, err := m.Pending()
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestCheckSchema_AutoMigrateFailUnavailable(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := database.NewMigrator(db, database.DriverSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+	root := &cobra.Command{Use: "quiz_master"}
+	child := &cobra.Command{Use: "list_question", Run: func(cmd *cobra.Command, args []string) {}}
+	root.AddCommand(child)
+
+	err = checkSchema(m, true)(child, nil)
+	assert.ErrorIs(t, err, domain.ErrUnavailable)
+	assert.Equal(t, ExitUnavailable, ExitCode(err))
+}
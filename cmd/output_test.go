@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"quiz_master/domain"
+	"quiz_master/domain/mocks"
+	"quiz_master/helper"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// executeWithOutput runs cmd under a parent having --output, returning what
+// it wrote to its output and error streams.
+func executeWithOutput(cmd *cobra.Command, args ...string) (string, string) {
+	root := &cobra.Command{Use: "quiz_master"}
+	addOutputFlag(root)
+	root.AddCommand(cmd)
+	out, errOut := bytes.NewBufferString(""), bytes.NewBufferString("")
+	root.SetOut(out)
+	root.SetErr(errOut)
+	root.SetArgs(append([]string{cmd.Name()}, args...))
+	run(root)
+	return out.String(), errOut.String()
+}
+
+func listQuestionUsecase() *mocks.QuestionUsecase {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("List", mock.Anything).Return(domain.QuestionPage{
+		Questions: []*domain.Question{{Number: "1", Question: "Capital of France?", Answer: "Paris", AnswerType: domain.AnswerTypeText, Tags: []string{"europe", "geography"}}},
+		Total:     1,
+	}, nil).Once()
+	return mockQuestionUsecase
+}
+
+func TestOutput_Table(t *testing.T) {
+	out, _ := executeWithOutput(NewListQuestion(listQuestionUsecase()), "-o", "table")
+	assert.Equal(t, "No |\tQuestion\t|\tAnswer\n1\tCapital of France?\t\t\tParis\n\nQuestions 1-1 of 1, page 1 of 1\n", out)
+}
+
+func TestOutput_JSON(t *testing.T) {
+	out, _ := executeWithOutput(NewListQuestion(listQuestionUsecase()), "--output", "json")
+	assert.JSONEq(t, `{"questions": [{"id": 0, "number": "1", "question": "Capital of France?", "answer": "Paris", "answer_type": "text",
+		"grading": {}, "tags": ["europe", "geography"]}], "offset": 0, "limit": 20, "total": 1}`, out)
+}
+
+func TestOutput_YAML(t *testing.T) {
+	out, _ := executeWithOutput(NewListQuestion(listQuestionUsecase()), "-o", "yaml")
+	assert.Equal(t, `questions:
+  - id: 0
+    number: "1"
+    question: Capital of France?
+    answer: Paris
+    answer_type: text
+    grading: {}
+    tags:
+      - europe
+      - geography
+offset: 0
+limit: 20
+total: 1
+`, out)
+}
+
+func TestOutput_CSV(t *testing.T) {
+	out, _ := executeWithOutput(NewListQuestion(listQuestionUsecase()), "-o", "csv")
+	assert.Equal(t, "number,question,answer,answer_type,lang,grading,fuzzy,time_limit,choices,hints,explanation,tags,aliases,deleted_at\n"+
+		"1,Capital of France?,Paris,text,,,0,,,,,europe;geography,,\n", out)
+}
+
+func TestOutput_Errors(t *testing.T) {
+	for format, expected := range map[string]string{
+		helper.FormatTable: "Question not found\n",
+		helper.FormatJSON:  "{\n  \"error\": \"Question not found\"\n}\n",
+		helper.FormatYAML:  "error: Question not found\n",
+		helper.FormatCSV:   "error\nQuestion not found\n",
+	} {
+		mockQuestionUsecase := new(mocks.QuestionUsecase)
+		mockQuestionUsecase.On("GetByNumber", "9").Return(domain.Question{}, fmt.Errorf("Question not found")).Once()
+		out, errOut := executeWithOutput(NewQuestionCmd(mockQuestionUsecase), "9", "-o", format)
+		assert.Equal(t, "", out, format)
+		assert.Equal(t, expected, errOut, format)
+	}
+}
+
+func TestOutput_ValidationError(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Store", mock.Anything).Return(domain.Invalid("number", "Number must be a valid numeric value")).Once()
+	out, errOut := executeWithOutput(NewCreateQuestion(mockQuestionUsecase), "abc", "lorem?", "1", "-o", "json")
+	assert.Equal(t, "", out)
+	assert.JSONEq(t, `{"error": "Number must be a valid numeric value",
+		"fields": [{"field": "number", "message": "Number must be a valid numeric value"}]}`, errOut)
+}
+
+func TestOutput_UnknownFormat(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	_, errOut := executeWithOutput(NewListTagsCmd(mockQuestionUsecase), "-o", "xml")
+	assert.Contains(t, errOut, `invalid argument "xml" for "-o, --output" flag: output must be one of table, json, yaml or csv`)
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestOutput_PlayPromptsOnStderr(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("GetAll", domain.QuestionFilter{}).Return(playQuestions()[:1], nil).Once()
+	mockQuestionUsecase.On("AnswerQuestion", "1", "2").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "2", Feedback: "Correct!"}, nil).Once()
+
+	cmd := NewPlayCmd(mockQuestionUsecase, acceptAttempts(), helper.SystemClock)
+	cmd.SetIn(bytes.NewBufferString("2\n"))
+	out, errOut := executeWithOutput(cmd, "--player", "bob", "-o", "csv")
+	assert.Equal(t, "Question 1/1 (no 1)\nQ : 1 + 1?\n> Correct!\n\n", errOut)
+	assert.Regexp(t, "^answered_at,player,question_number,answer,correct,timed_out,score,hints_used,duration\n"+
+		".*,bob,1,2,true,false,1,0,.*\n$", out)
+}
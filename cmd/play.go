@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"quiz_master/domain"
+	"quiz_master/helper"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewPlayCmd builds the play command, timing the answers with clock.
+func NewPlayCmd(u domain.QuestionUsecase, a domain.AttemptUsecase, clock helper.Clock) *cobra.Command {
+	var count int
+	var timeLimit time.Duration
+	var player string
+	var shuffle bool
+	var seed int64
+	var numbers []string
+	var filter domain.QuestionFilter
+	playCmd := &cobra.Command{
+		Use:   "play [--count 10] [--shuffle [--seed N]] [--numbers 1,3,5] [--tag geography] [--time-limit 5m]",
+		Short: "This command is use to answer questions one after another and get a score",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			questions, err := u.GetAll(filter)
+			if err != nil {
+				return err
+			}
+
+			var rnd *rand.Rand
+			if shuffle {
+				if !cmd.Flags().Changed("seed") {
+					seed = clock.Now().UnixNano()
+				}
+				rnd = rand.New(rand.NewSource(seed))
+			}
+			questions, err = helper.PickQuestions(questions, numbers, count, rnd)
+			if err != nil {
+				return err
+			}
+			if len(questions) == 0 {
+				return domain.Errorf(domain.ErrNotFound, "No questions to play")
+			}
+
+			if timeLimit < 0 {
+				return domain.Invalid("time-limit", "Time limit can't be negative")
+			}
+
+			session := &playSession{questions: u, attempts: a, clock: clock, timeLimit: timeLimit}
+			out.Render(helper.PlayView(session.play(out, cmd.InOrStdin(), currentPlayer(player), questions)))
+			return nil
+		},
+	}
+	playCmd.Flags().IntVar(&count, "count", 0, "number of questions to ask, all of them by default")
+	playCmd.Flags().BoolVar(&shuffle, "shuffle", false, "ask the questions in random order")
+	playCmd.Flags().Int64Var(&seed, "seed", 0, "seed of --shuffle, to replay the same order")
+	playCmd.Flags().StringSliceVar(&numbers, "numbers", nil, "numbers of the questions to ask, in this order")
+	playCmd.Flags().DurationVar(&timeLimit, "time-limit", 0, "time to answer all the questions, e.g. 5m, no limit by default")
+	addTagFlags(playCmd, &filter.Tags)
+	playCmd.Flags().StringVar(&player, "player", "", "who plays, defaults to the player setting or the user logged in")
+	return playCmd
+}
+
+// playSession runs a quiz. timeLimit bounds the whole quiz and each
+// question may have its own limit; zero means no limit.
+type playSession struct {
+	questions domain.QuestionUsecase
+	attempts  domain.AttemptUsecase
+	clock     helper.Clock
+	timeLimit time.Duration
+}
+
+// play asks questions one by one, reading the answers of player from input
+// and writing the rest to the log of r, and returns how the quiz went. It
+// stops early when the input runs out or the quiz runs out of time. An
+// answer given after its limit is recorded as timed out and counts as wrong.
+func (s *playSession) play(r helper.Renderer, input io.Reader, player string, questions []*domain.Question) helper.PlayOutput {
+	out := r.Log()
+	in := bufio.NewScanner(input)
+	result := helper.PlayOutput{Player: player, Attempts: []*domain.Attempt{}}
+	var deadline time.Time
+	if s.timeLimit > 0 {
+		deadline = s.clock.Now().Add(s.timeLimit)
+	}
+	for i, q := range questions {
+		limit := q.TimeLimit
+		if !deadline.IsZero() {
+			left := deadline.Sub(s.clock.Now())
+			if left <= 0 {
+				fmt.Fprintf(out, "Time's up, %d of %d questions left unanswered\n\n", len(questions)-i, len(questions))
+				break
+			}
+			if limit <= 0 || left < limit {
+				limit = left
+			}
+		}
+
+		fmt.Fprintf(out, "Question %d/%d (no %s)\nQ : %s\n", i+1, len(questions), q.Number, q.Question)
+		helper.WriteChoices(out, q.Choices)
+		if limit > 0 {
+			fmt.Fprintf(out, "(%s left) > ", roundUp(limit))
+		} else {
+			fmt.Fprint(out, "> ")
+		}
+		prompted := s.clock.Now()
+		if !in.Scan() {
+			fmt.Fprintln(out)
+			break
+		}
+		result.Answered++
+		answeredAt := s.clock.Now()
+		duration := answeredAt.Sub(prompted)
+
+		answer := strings.TrimSpace(in.Text())
+		grade, err := s.questions.AnswerQuestion(q.Number, answer)
+		if err != nil {
+			fmt.Fprintf(out, "%s\n\n", err.Error())
+			continue
+		}
+		late := limit > 0 && duration > limit
+		if late {
+			result.TimedOut++
+			grade.Outcome = domain.OutcomeIncorrect
+			grade.Score = 0
+			grade.Feedback = helper.Message(grade.Lang, helper.MessageTimedOut)
+		}
+		fmt.Fprintln(out, grade.Feedback)
+		if grade.Outcome != domain.OutcomeCorrect {
+			fmt.Fprintf(out, "A : %s\n", q.Answer)
+		} else {
+			result.Correct++
+		}
+		if grade.Explanation != "" {
+			fmt.Fprintf(out, "Explanation : %s\n", grade.Explanation)
+		}
+		attempt := &domain.Attempt{
+			Player:         player,
+			QuestionNumber: q.Number,
+			Answer:         answer,
+			Correct:        grade.Outcome == domain.OutcomeCorrect,
+			TimedOut:       late,
+			Score:          grade.Score,
+			AnsweredAt:     answeredAt,
+			Duration:       duration,
+		}
+		recordAttempt(r, s.attempts, attempt)
+		result.Attempts = append(result.Attempts, attempt)
+		result.Score += attempt.Score
+		fmt.Fprintln(out)
+	}
+	return result
+}
+
+// roundUp rounds d up to the second, so a prompt never shows 0s left while
+// there is still time.
+func roundUp(d time.Duration) time.Duration {
+	if r := d.Truncate(time.Second); r < d {
+		return r + time.Second
+	}
+	return d
+}
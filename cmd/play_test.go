@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"quiz_master/builder"
+	"quiz_master/domain"
+	"quiz_master/domain/mocks"
+	"quiz_master/helper"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func playQuestions() []*domain.Question {
+	return []*domain.Question{
+		builder.NewQuestion(builder.SetNumber("1"), builder.SetQuestion("1 + 1?"), builder.SetAnswer("2")),
+		builder.NewQuestion(builder.SetNumber("2"), builder.SetQuestion("Capital of France?"), builder.SetAnswer("Paris"),
+			builder.SetAnswerType(domain.AnswerTypeText)),
+		builder.NewQuestion(builder.SetNumber("3"), builder.SetQuestion("2 * 3?"), builder.SetAnswer("6")),
+	}
+}
+
+func TestPlay_Success(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("GetAll", domain.QuestionFilter{}).Return(playQuestions(), nil).Once()
+	mockQuestionUsecase.On("AnswerQuestion", "1", "two").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "2", Feedback: "Correct!"}, nil).Once()
+	mockQuestionUsecase.On("AnswerQuestion", "2", "Rome").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeIncorrect, Feedback: "Wrong Answer!"}, nil).Once()
+	mockQuestionUsecase.On("AnswerQuestion", "3", "6").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "6", Feedback: "Correct!"}, nil).Once()
+
+	mockAttemptUsecase := new(mocks.AttemptUsecase)
+	for _, a := range []struct {
+		number, answer string
+		correct        bool
+	}{{"1", "two", true}, {"2", "Rome", false}, {"3", "6", true}} {
+		a := a
+		mockAttemptUsecase.On("Record", mock.MatchedBy(func(attempt *domain.Attempt) bool {
+			return attempt.Player == "bob" && attempt.QuestionNumber == a.number && attempt.Answer == a.answer && attempt.Correct == a.correct
+		})).Return(nil).Once()
+	}
+
+	cmd := NewPlayCmd(mockQuestionUsecase, mockAttemptUsecase, helper.SystemClock)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetIn(bytes.NewBufferString("two\n Rome \n6\n"))
+	cmd.SetArgs([]string{"--player", "bob"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question 1/3 (no 1)\nQ : 1 + 1?\n> Correct!\n\n"+
+		"Question 2/3 (no 2)\nQ : Capital of France?\n> Wrong Answer!\nA : Paris\n\n"+
+		"Question 3/3 (no 3)\nQ : 2 * 3?\n> Correct!\n\n"+
+		"Score : 2/3, 2 of 3 answered correctly\n")
+	mockQuestionUsecase.AssertExpectations(t)
+	mockAttemptUsecase.AssertExpectations(t)
+}
+
+func TestPlay_NumbersAndCount(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("GetAll", domain.QuestionFilter{}).Return(playQuestions(), nil).Once()
+	mockQuestionUsecase.On("AnswerQuestion", "3", "6").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "6", Feedback: "Correct!"}, nil).Once()
+
+	cmd := NewPlayCmd(mockQuestionUsecase, acceptAttempts(), helper.SystemClock)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetIn(bytes.NewBufferString("6\n"))
+	cmd.SetArgs([]string{"--numbers", "3,1", "--count", "1"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question 1/1 (no 3)\nQ : 2 * 3?\n> Correct!\n\nScore : 1/1, 1 of 1 answered correctly\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestPlay_StopsAtEndOfInput(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("GetAll", domain.QuestionFilter{}).Return(playQuestions(), nil).Once()
+	mockQuestionUsecase.On("AnswerQuestion", "1", "3").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeIncorrect, Feedback: "Wrong Answer!"}, nil).Once()
+
+	cmd := NewPlayCmd(mockQuestionUsecase, acceptAttempts(), helper.SystemClock)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetIn(bytes.NewBufferString("3\n"))
+	cmd.SetArgs([]string{"--count", "2"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question 1/2 (no 1)\nQ : 1 + 1?\n> Wrong Answer!\nA : 2\n\n"+
+		"Question 2/2 (no 2)\nQ : Capital of France?\n> \nScore : 0/1, 0 of 1 answered correctly\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestPlay_ShuffleWithSeed(t *testing.T) {
+	order := func() string {
+		mockQuestionUsecase := new(mocks.QuestionUsecase)
+		mockQuestionUsecase.On("GetAll", domain.QuestionFilter{}).Return(playQuestions(), nil).Once()
+		cmd := NewPlayCmd(mockQuestionUsecase, acceptAttempts(), helper.SystemClock)
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetIn(bytes.NewBufferString(""))
+		cmd.SetArgs([]string{"--shuffle", "--seed", "42", "--count", "1"})
+		run(cmd)
+		return b.String()
+	}
+	assert.Equal(t, order(), order())
+}
+
+func TestPlay_FailUnknownNumber(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("GetAll", domain.QuestionFilter{}).Return(playQuestions(), nil).Once()
+
+	cmd := NewPlayCmd(mockQuestionUsecase, acceptAttempts(), helper.SystemClock)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--numbers", "1,9"})
+	assert.Equal(t, ExitNotFound, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 9 not found\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+// fakeClock only moves when a test tells it to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// slowInput gives one line per read, after the player thought about it for
+// the matching delay.
+type slowInput struct {
+	clock  *fakeClock
+	lines  []string
+	delays []time.Duration
+}
+
+func (s *slowInput) Read(p []byte) (int, error) {
+	if len(s.lines) == 0 {
+		return 0, io.EOF
+	}
+	s.clock.now = s.clock.now.Add(s.delays[0])
+	n := copy(p, s.lines[0]+"\n")
+	s.lines, s.delays = s.lines[1:], s.delays[1:]
+	return n, nil
+}
+
+func TestPlay_TimeLimits(t *testing.T) {
+	questions := playQuestions()
+	questions[0].TimeLimit = 10 * time.Second
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("GetAll", domain.QuestionFilter{}).Return(questions, nil).Once()
+	mockQuestionUsecase.On("AnswerQuestion", "1", "2").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "2", Feedback: "Correct!"}, nil).Once()
+	mockQuestionUsecase.On("AnswerQuestion", "2", "Paris").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "Paris", Feedback: "Correct!", Lang: "fr"}, nil).Once()
+
+	clock := &fakeClock{now: time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC)}
+	mockAttemptUsecase := new(mocks.AttemptUsecase)
+	mockAttemptUsecase.On("Record", mock.MatchedBy(func(attempt *domain.Attempt) bool {
+		return attempt.QuestionNumber == "1" && attempt.Correct && !attempt.TimedOut && attempt.Duration == 3*time.Second &&
+			attempt.AnsweredAt.Equal(time.Date(2021, 3, 1, 9, 0, 3, 0, time.UTC))
+	})).Return(nil).Once()
+	mockAttemptUsecase.On("Record", mock.MatchedBy(func(attempt *domain.Attempt) bool {
+		return attempt.QuestionNumber == "2" && !attempt.Correct && attempt.TimedOut && attempt.Score == 0
+	})).Return(nil).Once()
+
+	cmd := NewPlayCmd(mockQuestionUsecase, mockAttemptUsecase, clock)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetIn(&slowInput{clock: clock, lines: []string{"2", "Paris", "6"},
+		delays: []time.Duration{3 * time.Second, 17500 * time.Millisecond, time.Second}})
+	cmd.SetArgs([]string{"--time-limit", "20s"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question 1/3 (no 1)\nQ : 1 + 1?\n(10s left) > Correct!\n\n"+
+		"Question 2/3 (no 2)\nQ : Capital of France?\n(17s left) > Temps écoulé !\nA : Paris\n\n"+
+		"Time's up, 1 of 3 questions left unanswered\n\n"+
+		"Score : 1/2, 1 of 2 answered correctly, 1 timed out\n")
+	mockQuestionUsecase.AssertExpectations(t)
+	mockAttemptUsecase.AssertExpectations(t)
+}
@@ -16,15 +16,24 @@ limitations under the License.
 package cmd
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"os"
+	"quiz_master/builder"
 	"quiz_master/database"
 	"quiz_master/domain"
+	"quiz_master/dto"
 	"quiz_master/helper"
+	"quiz_master/number"
 	"quiz_master/repository"
 	"quiz_master/usecase"
+	"strings"
+	"time"
 
 	_ "github.com/joho/godotenv/autoload"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // questionCmd represents the question command
@@ -33,46 +42,282 @@ func NewQuestionCmd(u domain.QuestionUsecase) *cobra.Command {
 		Use:   "question <number>",
 		Short: "This command is use to show detail question",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
 			question, err := u.GetByNumber(args[0])
 			if err != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), err.Error()+"\n")
-				return
+				return err
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Q : "+question.Question+"\nA : "+question.Answer+"\n")
+			out.Render(helper.QuestionView(question))
+			return nil
 		},
 	}
 }
 
-func NewAnswerQuestionCmd(u domain.QuestionUsecase) *cobra.Command {
-	return &cobra.Command{
+func NewAnswerQuestionCmd(u domain.QuestionUsecase, a domain.AttemptUsecase) *cobra.Command {
+	var verbose bool
+	var player string
+	answerCmd := &cobra.Command{
 		Use:   "answer_question <number> <answer>",
 		Short: "This command to answer the question",
 		Args:  cobra.ExactArgs(2),
-		Run: func(cmd *cobra.Command, args []string) {
-			if err := u.AnswerQuestion(args); err != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), err.Error()+"\n")
-				return
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			result, err := u.AnswerQuestion(args[0], args[1])
+			if err != nil {
+				return err
 			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "Correct!\n")
+			attempt := &domain.Attempt{
+				Player:         currentPlayer(player),
+				QuestionNumber: args[0],
+				Answer:         args[1],
+				Correct:        result.Outcome == domain.OutcomeCorrect,
+				Score:          result.Score,
+			}
+			// a failure to record is told after the grade, like recordAttempt does
+			err = a.Record(attempt)
+			out.Render(helper.AnswerView(helper.AnswerOutput{AnswerResult: result, HintsUsed: attempt.HintsUsed, ScoreAfterHints: attempt.Score}, verbose))
+			if err != nil {
+				reportNotRecorded(out, err)
+			}
+			if result.Outcome != domain.OutcomeCorrect {
+				// the grade was shown, only the exit status is left to tell
+				return shown(domain.NewError(domain.ErrWrongAnswer, errors.New(result.Feedback)))
+			}
+			return nil
 		},
 	}
+	answerCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "tell which accepted answer matched, or the score of a wrong answer")
+	answerCmd.Flags().StringVar(&player, "player", "", "who answers, defaults to the player setting or the user logged in")
+	return answerCmd
 }
 
-func NewCreateQuestion(u domain.QuestionUsecase) *cobra.Command {
+func NewAddAliasCmd(u domain.QuestionUsecase) *cobra.Command {
 	return &cobra.Command{
+		Use:   "add_alias <number> <alias>",
+		Short: "This command is use to accept another answer for a question",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			if err := u.AddAlias(args[0], args[1]); err != nil {
+				return err
+			}
+			out.Render(helper.TextView(fmt.Sprintf("Question no %s now also accepts %q", args[0], args[1])))
+			return nil
+		},
+	}
+}
+
+func NewRemoveAliasCmd(u domain.QuestionUsecase) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove_alias <number> <alias>",
+		Short: "This command is use to stop accepting an alias of a question",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			if err := u.RemoveAlias(args[0], args[1]); err != nil {
+				return err
+			}
+			out.Render(helper.TextView(fmt.Sprintf("Question no %s no longer accepts %q", args[0], args[1])))
+			return nil
+		},
+	}
+}
+
+// gradingFlags reads the grading rule given with --tolerance,
+// --tolerance-percent or --range, at most one of them. ok is false when none
+// was given.
+func gradingFlags(cmd *cobra.Command, tolerance, percent, valueRange string) (grading domain.Grading, ok bool, err error) {
+	given := 0
+	if cmd.Flags().Changed("tolerance") {
+		grading = domain.Grading{Rule: domain.GradingAbsolute, Tolerance: tolerance}
+		given++
+	}
+	if cmd.Flags().Changed("tolerance-percent") {
+		grading = domain.Grading{Rule: domain.GradingRelative, Tolerance: strings.TrimSuffix(percent, "%")}
+		given++
+	}
+	if cmd.Flags().Changed("range") {
+		min, max, err := helper.ParseRange(valueRange)
+		if err != nil {
+			return grading, false, err
+		}
+		grading = domain.Grading{Rule: domain.GradingRange, Min: min, Max: max}
+		given++
+	}
+	if given > 1 {
+		return grading, false, domain.Invalid("grading", "use only one of --tolerance, --tolerance-percent and --range")
+	}
+	return grading, given == 1, nil
+}
+
+func addGradingFlags(cmd *cobra.Command, tolerance, percent, valueRange *string) {
+	cmd.Flags().StringVar(tolerance, "tolerance", "", "accept numeric answers within this distance of the answer")
+	cmd.Flags().StringVar(percent, "tolerance-percent", "", "accept numeric answers within this percentage of the answer")
+	cmd.Flags().StringVar(valueRange, "range", "", "accept numeric answers between MIN..MAX inclusive")
+}
+
+func NewCreateQuestion(u domain.QuestionUsecase) *cobra.Command {
+	var answerType, lang, tolerance, percent, valueRange, explanation string
+	var fuzzy int
+	var timeLimit time.Duration
+	var choices, correct, hints []string
+	var upsert bool
+	createCmd := &cobra.Command{
 		Use:   "create_question <number> <question> <answer>",
-		Args:  cobra.ExactArgs(3),
+		Args:  cobra.RangeArgs(2, 3),
 		Short: "This command use to create question",
-		Run: func(cmd *cobra.Command, args []string) {
-			if err := u.Store(args); err != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), err.Error()+"\n")
-				return
+		Long: `This command use to create question.
+
+Multiple choice questions take their options from --choice and the correct
+ones from --correct instead of an answer:
+
+  quiz_master create_question 1 "Capital of France?" --choice "A=Paris" --choice "B=Rome" --correct A
+
+A number already in use is refused unless --upsert is given, which replaces
+that question in place instead.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			options := []builder.Option{
+				builder.SetNumber(args[0]),
+				builder.SetQuestion(args[1]),
+				builder.SetAnswerType(answerType),
+				builder.SetLang(lang),
+				builder.SetFuzzy(fuzzy),
+				builder.SetTimeLimit(timeLimit),
+				builder.SetHints(hints...),
+				builder.SetExplanation(explanation),
+			}
+			if len(args) == 3 {
+				options = append(options, builder.SetAnswer(args[2]))
+			}
+			if len(choices) > 0 {
+				parsed, err := helper.ParseChoices(choices, correct)
+				if err != nil {
+					return err
+				}
+				if !cmd.Flags().Changed("type") {
+					options = append(options, builder.SetAnswerType(domain.AnswerTypeChoice))
+				}
+				options = append(options, builder.SetChoices(parsed))
+			}
+			grading, ok, err := gradingFlags(cmd, tolerance, percent, valueRange)
+			if err != nil {
+				return err
+			}
+			if ok {
+				options = append(options, builder.SetGrading(grading))
+			}
+
+			q := builder.NewQuestion(options...)
+			if upsert {
+				created, err := u.Upsert(q)
+				if err != nil {
+					return err
+				}
+				if created {
+					out.Render(helper.CreatedQuestionView(*q))
+				} else {
+					out.Render(helper.ReplacedQuestionView(*q))
+				}
+				return nil
+			}
+			if err := u.Store(q); err != nil {
+				return err
+			}
+			out.Render(helper.CreatedQuestionView(*q))
+			return nil
+		},
+	}
+	createCmd.Flags().StringVar(&answerType, "type", domain.AnswerTypeNumeric, "answer type, numeric, text or choice")
+	createCmd.Flags().StringVar(&lang, "lang", "", "language of number words accepted as answer, e.g. id; defaults to the lang setting")
+	createCmd.Flags().StringArrayVar(&choices, "choice", nil, "option of a multiple choice question as LABEL=TEXT, repeatable")
+	createCmd.Flags().StringSliceVar(&correct, "correct", nil, "labels of the correct options, repeatable or comma separated")
+	addGradingFlags(createCmd, &tolerance, &percent, &valueRange)
+	createCmd.Flags().IntVar(&fuzzy, "fuzzy", 0, "number of typos a text answer may have and still match")
+	createCmd.Flags().DurationVar(&timeLimit, "time-limit", 0, "time to answer the question when playing, e.g. 30s, no limit by default")
+	createCmd.Flags().StringArrayVar(&hints, "hint", nil, "hint revealed by the hint command, repeatable in the order to reveal them")
+	createCmd.Flags().StringVar(&explanation, "explanation", "", "explanation shown once the question is answered")
+	createCmd.Flags().BoolVar(&upsert, "upsert", false, "replace the question if the number is already in use")
+	return createCmd
+}
+
+func NewUpdateQuestionCmd(u domain.QuestionUsecase) *cobra.Command {
+	var number, question, answer, answerType, lang, tolerance, percent, valueRange, explanation string
+	var fuzzy int
+	var timeLimit time.Duration
+	var hints []string
+	var exact, clearHints bool
+	updateCmd := &cobra.Command{
+		Use:   "update_question <number> [--question ...] [--answer ...] [--number ...]",
+		Short: "This command is use to change some fields of a question",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			options := []builder.OptionRequestUpdate{}
+			if cmd.Flags().Changed("number") {
+				options = append(options, builder.UpdateWithNumber(number))
+			}
+			if cmd.Flags().Changed("question") {
+				options = append(options, builder.UpdateWithQuestion(question))
+			}
+			if cmd.Flags().Changed("answer") {
+				options = append(options, builder.UpdateWithAnswer(answer))
+			}
+			if cmd.Flags().Changed("type") {
+				options = append(options, builder.UpdateWithAnswerType(answerType))
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Question no "+args[0]+" created :\nQ : "+args[1]+"\nA : "+args[2]+"\n")
+			if cmd.Flags().Changed("lang") {
+				options = append(options, builder.UpdateWithLang(lang))
+			}
+			if cmd.Flags().Changed("fuzzy") {
+				options = append(options, builder.UpdateWithFuzzy(fuzzy))
+			}
+			if cmd.Flags().Changed("time-limit") {
+				options = append(options, builder.UpdateWithTimeLimit(timeLimit))
+			}
+			if cmd.Flags().Changed("hint") && clearHints {
+				return domain.Invalid("hint", "--clear-hints cannot be combined with --hint")
+			}
+			if cmd.Flags().Changed("hint") || clearHints {
+				options = append(options, builder.UpdateWithHints(hints))
+			}
+			if cmd.Flags().Changed("explanation") {
+				options = append(options, builder.UpdateWithExplanation(explanation))
+			}
+			grading, ok, err := gradingFlags(cmd, tolerance, percent, valueRange)
+			if err != nil {
+				return err
+			}
+			if ok && exact {
+				return domain.Invalid("grading", "--exact cannot be combined with another grading rule")
+			}
+			if ok || exact {
+				options = append(options, builder.UpdateWithGrading(dto.RequestGrading(grading)))
+			}
+
+			changes, err := u.Update(args[0], builder.NewRequestUpdate(options...))
+			if err != nil {
+				return err
+			}
+			out.Render(helper.UpdateView(args[0], changes))
+			return nil
 		},
 	}
+	updateCmd.Flags().StringVar(&number, "number", "", "new number of the question")
+	updateCmd.Flags().StringVar(&question, "question", "", "new question text")
+	updateCmd.Flags().StringVar(&answer, "answer", "", "new answer, the correct labels such as A,C for choice questions")
+	updateCmd.Flags().StringVar(&answerType, "type", "", "new answer type, numeric, text or choice")
+	updateCmd.Flags().StringVar(&lang, "lang", "", "new language of number words, empty for the lang setting")
+	addGradingFlags(updateCmd, &tolerance, &percent, &valueRange)
+	updateCmd.Flags().BoolVar(&exact, "exact", false, "only accept numeric answers equal to the answer")
+	updateCmd.Flags().IntVar(&fuzzy, "fuzzy", 0, "new number of typos a text answer may have, 0 to turn fuzzy matching off")
+	updateCmd.Flags().DurationVar(&timeLimit, "time-limit", 0, "new time to answer the question when playing, 0 for no limit")
+	updateCmd.Flags().StringArrayVar(&hints, "hint", nil, "new hints replacing all the others, repeatable in the order to reveal them")
+	updateCmd.Flags().BoolVar(&clearHints, "clear-hints", false, "remove all the hints")
+	updateCmd.Flags().StringVar(&explanation, "explanation", "", "new explanation, empty to remove it")
+	return updateCmd
 }
 
 // createQuestionCmd represents the createQuestion command
@@ -81,39 +326,170 @@ func NewDeleteQuestionCmd(u domain.QuestionUsecase) *cobra.Command {
 		Use:   "delete_question <number>",
 		Short: "This command is use to delete question",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
 			if err := u.Destroy(args[0]); err != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), err.Error()+"\n")
-				return
+				return err
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Question no "+args[0]+" was deleted!\n")
+			out.Render(helper.TextView("Question no " + args[0] + " was deleted!"))
+			return nil
 		},
 	}
 }
 
 func NewListQuestion(u domain.QuestionUsecase) *cobra.Command {
-	return &cobra.Command{
-		Use:   "list_question",
+	var options domain.QuestionListOptions
+	var page int
+	listCmd := &cobra.Command{
+		Use:   "list_question [--tag geography] [--page 1] [--per-page 20] [--sort number|created|question] [--desc]",
 		Short: "This command is use to list question",
-		Run: func(cmd *cobra.Command, args []string) {
-			questions, err := u.GetAll()
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			if page < 1 {
+				return domain.Invalid("page", "Page must be 1 or greater")
+			}
+			options.Offset = (page - 1) * options.Limit
+			questions, err := u.List(options)
 			if err != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), err.Error()+"\n")
+				return err
 			}
 
-			helper.ListQuestionResponse(questions)
+			out.Render(helper.ListQuestionView(questions, options.Limit))
+			return nil
+		},
+	}
+	addTagFlags(listCmd, &options.Filter.Tags)
+	listCmd.Flags().IntVar(&page, "page", 1, "page to show, starting at 1")
+	listCmd.Flags().IntVar(&options.Limit, "per-page", 20, "questions per page, 0 for all of them on one page")
+	listCmd.Flags().StringVar(&options.Sort, "sort", domain.SortNumber, "sort by number, created or question")
+	listCmd.Flags().BoolVar(&options.Desc, "desc", false, "sort in descending order")
+	return listCmd
+}
+
+func NewRestoreQuestionCmd(u domain.QuestionUsecase) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore_question <number>",
+		Short: "This command is use to restore a deleted question from the trash",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			if err := u.Restore(args[0]); err != nil {
+				return err
+			}
+			out.Render(helper.TextView(fmt.Sprintf("Question no %s was restored!", args[0])))
+			return nil
 		},
 	}
 }
 
+func NewListTrashCmd(u domain.QuestionUsecase) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list_trash",
+		Short: "This command is use to list deleted questions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			questions, err := u.GetTrashed()
+			if err != nil {
+				return err
+			}
+
+			out.Render(helper.ListTrashView(questions))
+			return nil
+		},
+	}
+}
+
+func NewPurgeTrashCmd(u domain.QuestionUsecase) *cobra.Command {
+	var olderThan string
+	purgeCmd := &cobra.Command{
+		Use:   "purge_trash",
+		Short: "This command is use to permanently delete questions from the trash",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			age, err := helper.ParseDuration(olderThan)
+			if err != nil {
+				return domain.Invalid("older-than", "%s", err)
+			}
+			if age < 0 {
+				return domain.Invalid("older-than", "Older than can't be negative")
+			}
+
+			purged, err := u.PurgeTrash(age)
+			if err != nil {
+				return err
+			}
+			out.Render(helper.PurgeView(purged))
+			return nil
+		},
+	}
+	purgeCmd.Flags().StringVar(&olderThan, "older-than", "", "only purge questions deleted at least this long ago, e.g. 30d")
+	return purgeCmd
+}
+
+func newQuestionRepository(driver string, db *sql.DB) domain.QuestionRepository {
+	switch driver {
+	case database.DriverSQLite:
+		return repository.NewSQLiteQuestionRepository(db)
+	case database.DriverPostgres:
+		return repository.NewPostgresQuestionRepository(db)
+	default:
+		return repository.NewQuestionRepository(db)
+	}
+}
+
 func InitCmd() {
+	if db, ok := lookupFlag(os.Args[1:], "db"); ok {
+		rootCmd.PersistentFlags().Set("db", db)
+	}
+	if config, ok := lookupFlag(os.Args[1:], "config"); ok {
+		cfgFile = config
+	}
+	initConfig()
+
+	driver := database.Driver(viper.GetString("db"))
+	db := database.InitDB(driver)
+	migrator, err := database.NewMigrator(db, driver)
+	cobra.CheckErr(err)
+	rootCmd.PersistentPreRunE = checkSchema(migrator, driver == database.DriverSQLite)
+
+	normalize, err := helper.ParseNormalizeOptions(viper.GetStringSlice("normalize"))
+	cobra.CheckErr(err)
+
+	langs := viper.GetStringSlice("lang")
+	for _, lang := range langs {
+		if !number.Supported(lang) {
+			cobra.CheckErr(fmt.Errorf("unsupported lang %q, use one of %s", lang, strings.Join(number.Languages(), ", ")))
+		}
+	}
 
-	db := database.InitDB()
-	repository := repository.NewQuestionRepository(db)
-	ucase := usecase.NewQuestionUsecase(repository)
+	repository := newQuestionRepository(driver, db)
+	attempts := usecase.NewAttemptUsecase(newAttemptRepository(driver, db),
+		usecase.WithHintPenalty(viper.GetFloat64("hint_penalty")))
+	ucase := usecase.NewQuestionUsecase(repository,
+		usecase.WithNormalization(normalize),
+		usecase.WithLanguages(langs),
+		usecase.WithFuzzyAccept(viper.GetBool("fuzzy_accept")))
 	rootCmd.AddCommand(NewQuestionCmd(ucase))
-	rootCmd.AddCommand(NewAnswerQuestionCmd(ucase))
+	rootCmd.AddCommand(NewAnswerQuestionCmd(ucase, attempts))
 	rootCmd.AddCommand(NewCreateQuestion(ucase))
+	rootCmd.AddCommand(NewUpdateQuestionCmd(ucase))
 	rootCmd.AddCommand(NewDeleteQuestionCmd(ucase))
 	rootCmd.AddCommand(NewListQuestion(ucase))
+	rootCmd.AddCommand(NewRestoreQuestionCmd(ucase))
+	rootCmd.AddCommand(NewListTrashCmd(ucase))
+	rootCmd.AddCommand(NewPurgeTrashCmd(ucase))
+	rootCmd.AddCommand(NewAddAliasCmd(ucase))
+	rootCmd.AddCommand(NewRemoveAliasCmd(ucase))
+	rootCmd.AddCommand(NewHintCmd(ucase, attempts))
+	rootCmd.AddCommand(NewTagQuestionCmd(ucase))
+	rootCmd.AddCommand(NewUntagQuestionCmd(ucase))
+	rootCmd.AddCommand(NewListTagsCmd(ucase))
+	rootCmd.AddCommand(NewSearchCmd(ucase))
+	rootCmd.AddCommand(NewPlayCmd(ucase, attempts, helper.SystemClock))
+	rootCmd.AddCommand(NewHistoryCmd(attempts))
+	rootCmd.AddCommand(NewScoreCmd(attempts))
+	rootCmd.AddCommand(NewLeaderboardCmd(attempts))
+	rootCmd.AddCommand(NewMigrateCmd(migrator))
 }
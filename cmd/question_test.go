@@ -7,7 +7,9 @@ import (
 	"quiz_master/builder"
 	"quiz_master/domain"
 	"quiz_master/domain/mocks"
+	"quiz_master/dto"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -26,7 +28,7 @@ func TestQuestion_Success(t *testing.T) {
 	b := bytes.NewBufferString("")
 	cmd.SetOut(b)
 	cmd.SetArgs([]string{mockQuestion.Number})
-	cmd.Execute()
+	run(cmd)
 	out, err := ioutil.ReadAll(b)
 	if err != nil {
 		t.Fatal(err)
@@ -40,9 +42,9 @@ func TestQuestion_Fail(t *testing.T) {
 
 	cmd := NewQuestionCmd(mockQuestionUsecase)
 	b := bytes.NewBufferString("")
-	cmd.SetOut(b)
+	cmd.SetErr(b)
 	cmd.SetArgs([]string{"1"})
-	cmd.Execute()
+	assert.Equal(t, ExitGeneric, run(cmd))
 	out, err := ioutil.ReadAll(b)
 	if err != nil {
 		t.Fatal(err)
@@ -52,13 +54,14 @@ func TestQuestion_Fail(t *testing.T) {
 
 func TestAnswerQuestion_Success(t *testing.T) {
 	mockQuestionUsecase := new(mocks.QuestionUsecase)
-	mockQuestionUsecase.On("AnswerQuestion", mock.Anything).Return(nil).Once()
+	mockQuestionUsecase.On("AnswerQuestion", mock.Anything, mock.Anything).
+		Return(domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "2", Feedback: "Correct!"}, nil).Once()
 
-	cmd := NewAnswerQuestionCmd(mockQuestionUsecase)
+	cmd := NewAnswerQuestionCmd(mockQuestionUsecase, acceptAttempts())
 	b := bytes.NewBufferString("")
 	cmd.SetOut(b)
 	cmd.SetArgs([]string{"1", "1"})
-	cmd.Execute()
+	assert.Equal(t, ExitOK, run(cmd))
 	out, err := ioutil.ReadAll(b)
 	if err != nil {
 		t.Fatal(err)
@@ -68,13 +71,14 @@ func TestAnswerQuestion_Success(t *testing.T) {
 
 func TestAnswerQuestion_Fail(t *testing.T) {
 	mockQuestionUsecase := new(mocks.QuestionUsecase)
-	mockQuestionUsecase.On("AnswerQuestion", mock.Anything).Return(fmt.Errorf("Wrong Answer!")).Once()
+	mockQuestionUsecase.On("AnswerQuestion", mock.Anything, mock.Anything).
+		Return(domain.AnswerResult{Outcome: domain.OutcomeIncorrect, Feedback: "Wrong Answer!"}, nil).Once()
 
-	cmd := NewAnswerQuestionCmd(mockQuestionUsecase)
+	cmd := NewAnswerQuestionCmd(mockQuestionUsecase, acceptAttempts())
 	b := bytes.NewBufferString("")
 	cmd.SetOut(b)
 	cmd.SetArgs([]string{"1", "1"})
-	cmd.Execute()
+	assert.Equal(t, ExitWrongAnswer, run(cmd))
 	out, err := ioutil.ReadAll(b)
 	if err != nil {
 		t.Fatal(err)
@@ -82,6 +86,39 @@ func TestAnswerQuestion_Fail(t *testing.T) {
 	assert.Equal(t, string(out), "Wrong Answer!\n")
 }
 
+func TestAnswerQuestion_FailQuestionNotFound(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("AnswerQuestion", "9", "1").Return(domain.AnswerResult{}, domain.Errorf(domain.ErrNotFound, "Question not found")).Once()
+
+	cmd := NewAnswerQuestionCmd(mockQuestionUsecase, acceptAttempts())
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"9", "1"})
+	assert.Equal(t, ExitNotFound, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question not found\n")
+}
+
+func TestAnswerQuestion_VerbosePartial(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("AnswerQuestion", "1", "A").
+		Return(domain.AnswerResult{Outcome: domain.OutcomePartial, Score: 0.5, Feedback: "Partly correct!"}, nil).Once()
+
+	cmd := NewAnswerQuestionCmd(mockQuestionUsecase, acceptAttempts())
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "A", "-v"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Partly correct! (score 0.50)\n")
+}
+
 func TestCreateQuestion_Success(t *testing.T) {
 	mockQuestionUsecase := new(mocks.QuestionUsecase)
 	mockQuestionUsecase.On("Store", mock.Anything).Return(nil).Once()
@@ -94,7 +131,7 @@ func TestCreateQuestion_Success(t *testing.T) {
 	b := bytes.NewBufferString("")
 	cmd.SetOut(b)
 	cmd.SetArgs([]string{mockQuestion.Number, mockQuestion.Question, mockQuestion.Answer})
-	cmd.Execute()
+	run(cmd)
 	out, err := ioutil.ReadAll(b)
 	if err != nil {
 		t.Fatal(err)
@@ -112,9 +149,9 @@ func TestCreateQuestion_Fail(t *testing.T) {
 	)
 	cmd := NewCreateQuestion(mockQuestionUsecase)
 	b := bytes.NewBufferString("")
-	cmd.SetOut(b)
+	cmd.SetErr(b)
 	cmd.SetArgs([]string{mockQuestion.Number, mockQuestion.Question, mockQuestion.Answer})
-	cmd.Execute()
+	assert.Equal(t, ExitGeneric, run(cmd))
 	out, err := ioutil.ReadAll(b)
 	if err != nil {
 		t.Fatal(err)
@@ -122,6 +159,47 @@ func TestCreateQuestion_Fail(t *testing.T) {
 	assert.Equal(t, string(out), "some error\n")
 }
 
+func TestCreateQuestion_FailAlreadyExists(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Store", mock.Anything).
+		Return(domain.Errorf(domain.ErrAlreadyExists, "Question no 1 already existed!")).Once()
+	cmd := NewCreateQuestion(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"1", "lorem ipsum dolor?", "2"})
+	assert.Equal(t, ExitConflict, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 already existed!\n")
+}
+
+func TestCreateQuestion_Upsert(t *testing.T) {
+	tests := []struct {
+		created bool
+		verb    string
+	}{
+		{true, "created"},
+		{false, "replaced"},
+	}
+	for _, tt := range tests {
+		mockQuestionUsecase := new(mocks.QuestionUsecase)
+		mockQuestionUsecase.On("Upsert", mock.Anything).Return(tt.created, nil).Once()
+		cmd := NewCreateQuestion(mockQuestionUsecase)
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetArgs([]string{"1", "lorem ipsum dolor?", "2", "--upsert"})
+		assert.Equal(t, ExitOK, run(cmd))
+		out, err := ioutil.ReadAll(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, string(out), "Question no 1 "+tt.verb+" :\nQ : lorem ipsum dolor?\nA : 2\n")
+		mockQuestionUsecase.AssertNotCalled(t, "Store", mock.Anything)
+	}
+}
+
 func TestDeleteQuestion_Success(t *testing.T) {
 	mockQuestionUsecase := new(mocks.QuestionUsecase)
 	mockQuestionUsecase.On("Destroy", mock.Anything).Return(nil).Once()
@@ -129,7 +207,7 @@ func TestDeleteQuestion_Success(t *testing.T) {
 	b := bytes.NewBufferString("")
 	cmd.SetOut(b)
 	cmd.SetArgs([]string{"1"})
-	cmd.Execute()
+	run(cmd)
 	out, err := ioutil.ReadAll(b)
 	if err != nil {
 		t.Fatal(err)
@@ -142,9 +220,9 @@ func TestDeleteQuestion_Fail(t *testing.T) {
 	mockQuestionUsecase.On("Destroy", mock.Anything).Return(fmt.Errorf("some error")).Once()
 	cmd := NewDeleteQuestionCmd(mockQuestionUsecase)
 	b := bytes.NewBufferString("")
-	cmd.SetOut(b)
+	cmd.SetErr(b)
 	cmd.SetArgs([]string{"1"})
-	cmd.Execute()
+	assert.Equal(t, ExitGeneric, run(cmd))
 	out, err := ioutil.ReadAll(b)
 	if err != nil {
 		t.Fatal(err)
@@ -154,29 +232,506 @@ func TestDeleteQuestion_Fail(t *testing.T) {
 
 func TestListQuestion_Success(t *testing.T) {
 	mockQuestionUsecase := new(mocks.QuestionUsecase)
-	mockQuestionUsecase.On("GetAll", mock.Anything).Return([]*domain.Question{}, nil).Once()
+	mockQuestionUsecase.On("List", domain.QuestionListOptions{Sort: domain.SortCreated, Desc: true, Limit: 2, Offset: 2}).Return(domain.QuestionPage{
+		Questions: []*domain.Question{{Number: "3", Question: "lorem?", Answer: "3"}, {Number: "4", Question: "ipsum?", Answer: "4"}},
+		Offset:    2,
+		Total:     5,
+	}, nil).Once()
 	cmd := NewListQuestion(mockQuestionUsecase)
 	b := bytes.NewBufferString("")
 	cmd.SetOut(b)
-	cmd.SetArgs([]string{})
-	cmd.Execute()
-	_, err := ioutil.ReadAll(b)
+	cmd.SetArgs([]string{"--page", "2", "--per-page", "2", "--sort", "created", "--desc"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "No |\tQuestion\t|\tAnswer\n3\tlorem?\t\t\t3\n4\tipsum?\t\t\t4\n\nQuestions 3-4 of 5, page 2 of 3\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestListQuestion_FailPage(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	cmd := NewListQuestion(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--page", "0"})
+	assert.Equal(t, ExitUsage, run(cmd))
+	out, err := ioutil.ReadAll(b)
 	if err != nil {
 		t.Fatal(err)
 	}
+	assert.Equal(t, string(out), "Page must be 1 or greater\n")
+	mockQuestionUsecase.AssertExpectations(t)
 }
 
 func TestListQuestion_Fail(t *testing.T) {
 	mockQuestionUsecase := new(mocks.QuestionUsecase)
-	mockQuestionUsecase.On("GetAll", mock.Anything).Return([]*domain.Question{}, fmt.Errorf("some error")).Once()
+	mockQuestionUsecase.On("List", mock.Anything).Return(domain.QuestionPage{}, fmt.Errorf("some error")).Once()
 	cmd := NewListQuestion(mockQuestionUsecase)
 	b := bytes.NewBufferString("")
-	cmd.SetOut(b)
+	cmd.SetErr(b)
 	cmd.SetArgs([]string{})
-	cmd.Execute()
+	assert.Equal(t, ExitGeneric, run(cmd))
 	out, err := ioutil.ReadAll(b)
 	if err != nil {
 		t.Fatal(err)
 	}
 	assert.Equal(t, string(out), "some error\n")
 }
+
+func TestRestoreQuestion_Success(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Restore", "1").Return(nil).Once()
+	cmd := NewRestoreQuestionCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 was restored!\n")
+}
+
+func TestRestoreQuestion_Fail(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Restore", "1").Return(domain.Errorf(domain.ErrNotFound, "Question not found in trash")).Once()
+	cmd := NewRestoreQuestionCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"1"})
+	assert.Equal(t, ExitNotFound, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question not found in trash\n")
+}
+
+func TestListTrash_Success(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	deletedAt := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+	mockQuestionUsecase.On("GetTrashed").Return([]*domain.Question{
+		{Number: "1", Question: "lorem ipsum dolor?", Answer: "2", DeletedAt: &deletedAt},
+	}, nil).Once()
+	cmd := NewListTrashCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "No |\tQuestion\t|\tDeleted at\n1\tlorem ipsum dolor?\t\t\t2021-05-01 10:00:00\n\n")
+}
+
+func TestPurgeTrash_Success(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("PurgeTrash", 30*24*time.Hour).Return(int64(2), nil).Once()
+	cmd := NewPurgeTrashCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--older-than", "30d"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "2 question(s) purged from the trash\n")
+}
+
+func TestPurgeTrash_FailInvalidDuration(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	cmd := NewPurgeTrashCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--older-than", "soon"})
+	assert.Equal(t, ExitUsage, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "invalid duration \"soon\"\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestPurgeTrash_FailNegativeDuration(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	cmd := NewPurgeTrashCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"--older-than", "-1h"})
+	assert.Equal(t, ExitUsage, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Older than can't be negative\n")
+	mockQuestionUsecase.AssertNotCalled(t, "PurgeTrash", mock.Anything)
+}
+
+func TestUpdateQuestion_Success(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Update", "1", builder.NewRequestUpdate(builder.UpdateWithAnswer("3"))).
+		Return([]domain.QuestionChange{{Field: "answer", Old: "2", New: "3"}}, nil).Once()
+	cmd := NewUpdateQuestionCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "--answer", "3"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 updated :\nanswer : 2 -> 3\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestUpdateQuestion_Unchanged(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Update", "1", builder.NewRequestUpdate()).Return([]domain.QuestionChange{}, nil).Once()
+	cmd := NewUpdateQuestionCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 is unchanged\n")
+}
+
+func TestUpdateQuestion_Fail(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Update", "1", mock.Anything).Return(nil, domain.Errorf(domain.ErrAlreadyExists, "Question no 2 already existed!")).Once()
+	cmd := NewUpdateQuestionCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"1", "--number", "2"})
+	assert.Equal(t, ExitConflict, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 2 already existed!\n")
+}
+
+func TestCreateQuestion_TextAnswer(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Store", mock.MatchedBy(func(q *domain.Question) bool {
+		return q.AnswerType == domain.AnswerTypeText && q.Answer == "Paris"
+	})).Return(nil).Once()
+	cmd := NewCreateQuestion(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "What is the capital of France?", "Paris", "--type", "text"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 created :\nQ : What is the capital of France?\nA : Paris\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestCreateQuestion_Choices(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Store", mock.MatchedBy(func(q *domain.Question) bool {
+		return q.AnswerType == domain.AnswerTypeChoice && q.Answer == "" &&
+			assert.ObjectsAreEqual([]domain.Choice{{Label: "A", Text: "Paris"}, {Label: "B", Text: "Rome", Correct: true}}, q.Choices)
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*domain.Question).Answer = "B"
+	}).Return(nil).Once()
+	cmd := NewCreateQuestion(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "Capital of Italy?", "--choice", "A=Paris", "--choice", "B=Rome", "--correct", "b"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 created :\nQ : Capital of Italy?\n    A) Paris\n    B) Rome\nA : B\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestCreateQuestion_FailInvalidChoice(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	cmd := NewCreateQuestion(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"1", "Capital of Italy?", "--choice", "A=Paris", "--choice", "B=Rome", "--correct", "C"})
+	assert.Equal(t, ExitUsage, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Choice C does not exist\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestQuestion_ShowsChoices(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestion := builder.NewQuestion(
+		builder.SetNumber("1"),
+		builder.SetQuestion("Capital of Italy?"),
+		builder.SetAnswer("B"),
+		builder.SetAnswerType(domain.AnswerTypeChoice),
+		builder.SetChoices([]domain.Choice{{Label: "A", Text: "Paris"}, {Label: "B", Text: "Rome", Correct: true}}),
+	)
+	mockQuestionUsecase.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+
+	cmd := NewQuestionCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Q : Capital of Italy?\n    A) Paris\n    B) Rome\nA : B\n")
+}
+
+func TestAnswerQuestion_VerboseAlias(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("AnswerQuestion", "1", "united states").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "United States", Alias: true, Feedback: "Correct!"}, nil).Once()
+
+	cmd := NewAnswerQuestionCmd(mockQuestionUsecase, acceptAttempts())
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "united states", "--verbose"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Correct! (matched alias \"United States\")\n")
+}
+
+func TestAddAlias_Success(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("AddAlias", "1", "United States").Return(nil).Once()
+	cmd := NewAddAliasCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "United States"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 now also accepts \"United States\"\n")
+}
+
+func TestRemoveAlias_Fail(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("RemoveAlias", "1", "America").Return(domain.Errorf(domain.ErrNotFound, "Question no 1 has no alias America")).Once()
+	cmd := NewRemoveAliasCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"1", "America"})
+	assert.Equal(t, ExitNotFound, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 has no alias America\n")
+}
+
+func TestAnswerQuestion_LocalizedMessage(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("AnswerQuestion", "1", "dua puluh satu").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "21", Feedback: "Benar!", Lang: "id"}, nil).Once()
+
+	cmd := NewAnswerQuestionCmd(mockQuestionUsecase, acceptAttempts())
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "dua puluh satu"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Benar!\n")
+}
+
+func TestCreateQuestion_Lang(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Store", mock.MatchedBy(func(q *domain.Question) bool {
+		return q.Lang == "id" && q.Answer == "21"
+	})).Return(nil).Once()
+	cmd := NewCreateQuestion(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "Berapa 20 + 1?", "21", "--lang", "id"})
+	run(cmd)
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestCreateQuestion_Tolerance(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Store", mock.MatchedBy(func(q *domain.Question) bool {
+		return q.Grading == domain.Grading{Rule: domain.GradingRelative, Tolerance: "5"}
+	})).Return(nil).Once()
+	cmd := NewCreateQuestion(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "Distance to the moon in km?", "384400", "--tolerance-percent", "5%"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 created :\nQ : Distance to the moon in km?\nA : 384400\nGrading : within ±5%\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestCreateQuestion_FailSeveralGradingRules(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	cmd := NewCreateQuestion(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"1", "Value of pi?", "3.14", "--tolerance", "0.01", "--range", "3..4"})
+	assert.Equal(t, ExitUsage, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "use only one of --tolerance, --tolerance-percent and --range\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestQuestion_ShowsGrading(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestion := builder.NewQuestion(
+		builder.SetNumber("1"),
+		builder.SetQuestion("Value of pi?"),
+		builder.SetAnswer("3.14"),
+		builder.SetGrading(domain.Grading{Rule: domain.GradingAbsolute, Tolerance: "0.01"}),
+	)
+	mockQuestionUsecase.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+
+	cmd := NewQuestionCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Q : Value of pi?\nA : 3.14\nGrading : within ±0.01\n")
+}
+
+func TestUpdateQuestion_Range(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	request := builder.NewRequestUpdate(builder.UpdateWithGrading(dto.RequestGrading{Rule: domain.GradingRange, Min: "-5", Max: "5"}))
+	mockQuestionUsecase.On("Update", "1", request).
+		Return([]domain.QuestionChange{{Field: "grading", Old: "exact", New: "between -5 and 5"}}, nil).Once()
+	cmd := NewUpdateQuestionCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "--range", "-5..5"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 updated :\ngrading : exact -> between -5 and 5\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestAnswerQuestion_CloseAccepted(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("AnswerQuestion", "1", "Parris").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "Paris", Distance: 1, Feedback: "Close, but accepted!", Lang: "en"}, nil).Once()
+	cmd := NewAnswerQuestionCmd(mockQuestionUsecase, acceptAttempts())
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "Parris", "--verbose"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Close, but accepted! (matched answer \"Paris\")\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestCreateQuestion_Fuzzy(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Store", mock.MatchedBy(func(q *domain.Question) bool {
+		return q.AnswerType == domain.AnswerTypeText && q.Fuzzy == 2
+	})).Return(nil).Once()
+	cmd := NewCreateQuestion(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "Capital of France?", "Paris", "--type", "text", "--fuzzy", "2"})
+	run(cmd)
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestUpdateQuestion_TimeLimit(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Update", "1", builder.NewRequestUpdate(builder.UpdateWithTimeLimit(30*time.Second))).
+		Return([]domain.QuestionChange{{Field: "time_limit", Old: "none", New: "30s"}}, nil).Once()
+	cmd := NewUpdateQuestionCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "--time-limit", "30s"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 updated :\ntime_limit : none -> 30s\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestAnswerQuestion_ExplanationAndHints(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("AnswerQuestion", "1", "Paris").
+		Return(domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "Paris", Feedback: "Correct!",
+			Explanation: "Paris has been the capital since 987."}, nil).Once()
+	mockAttemptUsecase := new(mocks.AttemptUsecase)
+	mockAttemptUsecase.On("Record", mock.Anything).Run(func(args mock.Arguments) {
+		attempt := args.Get(0).(*domain.Attempt)
+		attempt.HintsUsed = 2
+		attempt.Score = 0.5
+	}).Return(nil).Once()
+
+	cmd := NewAnswerQuestionCmd(mockQuestionUsecase, mockAttemptUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "Paris", "--player", "bob"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Correct!\nExplanation : Paris has been the capital since 987.\nHints used : 2, score 0.5\n")
+	mockAttemptUsecase.AssertExpectations(t)
+}
+
+func TestUpdateQuestion_Hints(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Update", "1", builder.NewRequestUpdate(builder.UpdateWithHints(nil))).
+		Return([]domain.QuestionChange{{Field: "hints", Old: "It's in Europe", New: "none"}}, nil).Once()
+	cmd := NewUpdateQuestionCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "--clear-hints"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 updated :\nhints : It's in Europe -> none\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
@@ -16,14 +16,24 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+
+	"quiz_master/domain"
+	"quiz_master/helper"
+
 	"github.com/spf13/cobra"
 
 	"github.com/spf13/viper"
 )
 
-var cfgFile string
+var (
+	cfgFile    string
+	dbDriver   string
+	configRead bool
+)
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -40,10 +50,126 @@ to quickly create a Cobra application.`,
 	// Run: func(cmd *cobra.Command, args []string) { },
 }
 
+// Exit codes of quiz_master, ExitCode tells which one an error gets.
+const (
+	ExitOK          = 0
+	ExitGeneric     = 1
+	ExitUsage       = 2
+	ExitNotFound    = 3
+	ExitWrongAnswer = 4
+	ExitConflict    = 5
+	ExitUnavailable = 10
+)
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	cobra.CheckErr(rootCmd.Execute())
+	os.Exit(run(rootCmd))
+}
+
+// run executes cmd, rendering the error it fails with on stderr in the
+// format of --output, and returns the exit code of that error. Usage errors
+// are followed by a pointer to --help in the table format.
+func run(cmd *cobra.Command) int {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return usageError{err}
+	})
+	markUsageErrors(cmd)
+
+	c, err := cmd.ExecuteC()
+	if err == nil {
+		return ExitOK
+	}
+	// a command without Run only fails on the command line, with an
+	// unknown subcommand for instance
+	if !c.Runnable() {
+		err = usageError{err}
+	}
+	out := renderer(c)
+	var s shownError
+	if !errors.As(err, &s) {
+		out.Error(err)
+	}
+	// the hint is for people, in the other formats stderr has to parse as a
+	// whole
+	var usage usageError
+	if errors.As(err, &usage) && out.Format() == helper.FormatTable {
+		fmt.Fprintf(c.ErrOrStderr(), "Run '%s --help' for usage.\n", c.CommandPath())
+	}
+	return ExitCode(err)
+}
+
+// ExitCode is the exit code of a command failing with err, from the kind
+// of domain error it is. An invalid value counts as a usage error, and a
+// failing database that is still reachable as a generic one.
+func ExitCode(err error) int {
+	var usage usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usage), errors.Is(err, domain.ErrValidation):
+		return ExitUsage
+	case errors.Is(err, domain.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, domain.ErrWrongAnswer):
+		return ExitWrongAnswer
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return ExitConflict
+	case errors.Is(err, domain.ErrUnavailable):
+		return ExitUnavailable
+	default:
+		return ExitGeneric
+	}
+}
+
+// usageError is a command line cobra refused, a missing argument or an
+// unknown flag.
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e usageError) Unwrap() error {
+	return e.err
+}
+
+// markUsageErrors makes the argument checks of cmd and its subcommands fail
+// with a usageError.
+func markUsageErrors(cmd *cobra.Command) {
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(c *cobra.Command, a []string) error {
+			if err := args(c, a); err != nil {
+				return usageError{err}
+			}
+			return nil
+		}
+	}
+	for _, c := range cmd.Commands() {
+		markUsageErrors(c)
+	}
+}
+
+// shownError is a failure the output of the command already told, run
+// only turns it into an exit code.
+type shownError struct {
+	err error
+}
+
+func shown(err error) error {
+	return shownError{err}
+}
+
+func (e shownError) Error() string {
+	return e.err.Error()
+}
+
+func (e shownError) Unwrap() error {
+	return e.err
 }
 
 func init() {
@@ -54,14 +180,34 @@ func init() {
 	// will be global for your application.
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.quiz_master.yaml)")
+	rootCmd.PersistentFlags().StringVar(&dbDriver, "db", "", "database backend, mysql, postgres or sqlite (default is $DB_DRIVER)")
+	viper.BindPFlag("db", rootCmd.PersistentFlags().Lookup("db"))
+	viper.BindEnv("db", "DB_DRIVER")
+	addOutputFlag(rootCmd)
+	// differences ignored when grading text answers
+	viper.SetDefault("normalize", []string{"case", "whitespace", "punctuation", "diacritics"})
+	// languages of the number words accepted as numeric answers
+	viper.SetDefault("lang", []string{"en"})
+	// whether text answers within a question's fuzzy threshold count as correct
+	viper.SetDefault("fuzzy_accept", true)
+	// who answers when --player is not given, empty for the user logged in
+	viper.SetDefault("player", "")
+	// score taken off an answer for each hint shown before it
+	viper.SetDefault("hint_penalty", 0.25)
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
-// initConfig reads in config file and ENV variables if set.
+// initConfig reads in config file and ENV variables if set. InitCmd needs
+// the configuration before cobra runs, so it may be called twice.
 func initConfig() {
+	if configRead {
+		return
+	}
+	configRead = true
+
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
@@ -83,3 +229,38 @@ func initConfig() {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
 }
+
+// addOutputFlag adds --output, which the commands below cmd render their
+// results with.
+func addOutputFlag(cmd *cobra.Command) {
+	format := helper.Format(helper.FormatTable)
+	cmd.PersistentFlags().VarP(&format, "output", "o", "output format, table, json, yaml or csv")
+}
+
+// renderer renders the results of cmd in the format of --output, table
+// when no parent of cmd has the flag.
+func renderer(cmd *cobra.Command) helper.Renderer {
+	format := helper.Format(helper.FormatTable)
+	if f := cmd.Flag("output"); f != nil {
+		format = helper.Format(f.Value.String())
+	}
+	return helper.NewRenderer(cmd.OutOrStdout(), cmd.ErrOrStderr(), format)
+}
+
+// lookupFlag returns the value of a long flag straight from the command line.
+// The repositories are wired before cobra parses the arguments, so flags such
+// as --db have to be read by hand.
+func lookupFlag(args []string, name string) (string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg == "--"+name && i+1 < len(args) {
+			return args[i+1], true
+		}
+		if strings.HasPrefix(arg, "--"+name+"=") {
+			return strings.TrimPrefix(arg, "--"+name+"="), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"quiz_master/domain"
+	"quiz_master/domain/mocks"
+	"quiz_master/helper"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupFlag(t *testing.T) {
+	tests := []struct {
+		args  []string
+		value string
+		found bool
+	}{
+		{[]string{"list_question", "--db", "sqlite"}, "sqlite", true},
+		{[]string{"--db=sqlite", "list_question"}, "sqlite", true},
+		{[]string{"list_question"}, "", false},
+		{[]string{"list_question", "--db"}, "", false},
+		{[]string{"create_question", "--", "--db", "sqlite"}, "", false},
+	}
+	for _, tt := range tests {
+		value, found := lookupFlag(tt.args, "db")
+		assert.Equal(t, tt.value, value)
+		assert.Equal(t, tt.found, found)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{nil, ExitOK},
+		{errors.New("some error"), ExitGeneric},
+		{usageError{errors.New("accepts 1 arg(s), received 0")}, ExitUsage},
+		{domain.Errorf(domain.ErrNotFound, "Question not found"), ExitNotFound},
+		{shown(domain.NewError(domain.ErrWrongAnswer, errors.New("Wrong Answer!"))), ExitWrongAnswer},
+		{fmt.Errorf("update: %w", domain.Errorf(domain.ErrAlreadyExists, "Question no 2 already existed!")), ExitConflict},
+		{domain.NewError(domain.ErrUnavailable, errors.New("connection refused")), ExitUnavailable},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.code, ExitCode(tt.err), fmt.Sprint(tt.err))
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	for _, args := range [][]string{{}, {"1", "--unknown"}} {
+		cmd := NewQuestionCmd(new(mocks.QuestionUsecase))
+		out, errOut := bytes.NewBufferString(""), bytes.NewBufferString("")
+		cmd.SetOut(out)
+		cmd.SetErr(errOut)
+		cmd.SetArgs(args)
+		assert.Equal(t, ExitUsage, run(cmd))
+		assert.Equal(t, "", out.String())
+		assert.Contains(t, errOut.String(), "Run 'question --help' for usage.\n")
+	}
+}
+
+func TestRun_UsageJSON(t *testing.T) {
+	root := &cobra.Command{Use: "quiz_master"}
+	addOutputFlag(root)
+	root.AddCommand(NewQuestionCmd(new(mocks.QuestionUsecase)))
+	errOut := bytes.NewBufferString("")
+	root.SetErr(errOut)
+	root.SetArgs([]string{"question", "1", "2", "-o", "json"})
+	assert.Equal(t, ExitUsage, run(root))
+
+	var output helper.ErrorOutput
+	assert.NoError(t, json.Unmarshal(errOut.Bytes(), &output))
+	assert.NotEmpty(t, output.Error)
+	assert.NotContains(t, errOut.String(), "--help")
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	root := &cobra.Command{Use: "quiz_master"}
+	root.AddCommand(NewQuestionCmd(new(mocks.QuestionUsecase)))
+	errOut := bytes.NewBufferString("")
+	root.SetErr(errOut)
+	root.SetArgs([]string{"qestion", "1"})
+	assert.Equal(t, ExitUsage, run(root))
+	assert.Contains(t, errOut.String(), `unknown command "qestion" for "quiz_master"`)
+}
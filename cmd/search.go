@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"quiz_master/domain"
+	"quiz_master/helper"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func NewSearchCmd(u domain.QuestionUsecase) *cobra.Command {
+	var query domain.SearchQuery
+	searchCmd := &cobra.Command{
+		Use:   "search <terms>...",
+		Short: "This command is use to search the questions, answers and explanations",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			query.Terms = strings.Join(args, " ")
+			results, err := u.Search(query)
+			if err != nil {
+				return err
+			}
+			out.Render(helper.SearchView(query.Terms, results))
+			return nil
+		},
+	}
+	searchCmd.Flags().BoolVar(&query.IncludeDeleted, "deleted", false, "also search the questions in the trash")
+	searchCmd.Flags().IntVar(&query.Limit, "limit", 10, "show at most this many questions, 0 for all")
+	return searchCmd
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"quiz_master/domain"
+	"quiz_master/domain/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch_Success(t *testing.T) {
+	deletedAt := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Search", domain.SearchQuery{Terms: "capital france", IncludeDeleted: true, Limit: 10}).Return([]domain.SearchResult{
+		{Question: domain.Question{Number: "1"}, Score: 2, Snippet: "**Capital** of **France**?"},
+		{Question: domain.Question{Number: "4", DeletedAt: &deletedAt}, Score: 0.5, Snippet: "Former **capital** of **France**?"},
+	}, nil).Once()
+	cmd := NewSearchCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"capital", "france", "--deleted"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "No |\tScore\t|\tSnippet\n1\t2\t\t**Capital** of **France**?\n4\t0.5\t\tFormer **capital** of **France**? (deleted)\n\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestSearch_NoResults(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("Search", domain.SearchQuery{Terms: "atlantis", Limit: 3}).Return([]domain.SearchResult{}, nil).Once()
+	cmd := NewSearchCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"atlantis", "--limit", "3"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "No question matches atlantis\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"quiz_master/domain"
+	"quiz_master/helper"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// addTagFlags adds --tag, which --category also spells, filling tags.
+func addTagFlags(cmd *cobra.Command, tags *[]string) {
+	cmd.Flags().StringSliceVar(tags, "tag", nil, "only questions with one of these tags, repeatable or comma separated (alias --category)")
+	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "category" {
+			name = "tag"
+		}
+		return pflag.NormalizedName(name)
+	})
+}
+
+func NewTagQuestionCmd(u domain.QuestionUsecase) *cobra.Command {
+	return &cobra.Command{
+		Use:   "tag_question <number> <tag>...",
+		Short: "This command is use to add tags or categories to a question",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			added, err := u.TagQuestion(args[0], args[1:])
+			if err != nil {
+				return err
+			}
+			out.Render(helper.TagView(args[0], added))
+			return nil
+		},
+	}
+}
+
+func NewUntagQuestionCmd(u domain.QuestionUsecase) *cobra.Command {
+	return &cobra.Command{
+		Use:   "untag_question <number> <tag>...",
+		Short: "This command is use to remove tags from a question",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			if err := u.UntagQuestion(args[0], args[1:]); err != nil {
+				return err
+			}
+			out.Render(helper.TextView(fmt.Sprintf("Question no %s untagged %s", args[0], strings.ToLower(strings.Join(args[1:], ", ")))))
+			return nil
+		},
+	}
+}
+
+func NewListTagsCmd(u domain.QuestionUsecase) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list_tags",
+		Short: "This command is use to list the tags with their number of questions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := renderer(cmd)
+			counts, err := u.ListTags()
+			if err != nil {
+				return err
+			}
+			out.Render(helper.ListTagsView(counts))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"quiz_master/domain"
+	"quiz_master/domain/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagQuestion_Success(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("TagQuestion", "1", []string{"Europe", "capitals"}).Return([]string{"europe", "capitals"}, nil).Once()
+	cmd := NewTagQuestionCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"1", "Europe", "capitals"})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 tagged europe, capitals\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestUntagQuestion_Fail(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("UntagQuestion", "1", []string{"asia"}).Return(domain.Errorf(domain.ErrNotFound, "Question no 1 has no tag asia")).Once()
+	cmd := NewUntagQuestionCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetErr(b)
+	cmd.SetArgs([]string{"1", "asia"})
+	assert.Equal(t, ExitNotFound, run(cmd))
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Question no 1 has no tag asia\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestListTags_Success(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("ListTags").Return([]domain.TagCount{{Tag: "europe", Questions: 12}, {Tag: "math", Questions: 3}}, nil).Once()
+	cmd := NewListTagsCmd(mockQuestionUsecase)
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{})
+	run(cmd)
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(out), "Tag |\tQuestions\neurope\t12\nmath\t3\n\n")
+	mockQuestionUsecase.AssertExpectations(t)
+}
+
+func TestListQuestion_FilterByCategory(t *testing.T) {
+	mockQuestionUsecase := new(mocks.QuestionUsecase)
+	mockQuestionUsecase.On("List", domain.QuestionListOptions{Filter: domain.QuestionFilter{Tags: []string{"europe", "math"}}, Sort: domain.SortNumber, Limit: 20}).Return(domain.QuestionPage{}, nil).Once()
+	cmd := NewListQuestion(mockQuestionUsecase)
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{"--tag", "europe", "--category", "math"})
+	run(cmd)
+	mockQuestionUsecase.AssertExpectations(t)
+}
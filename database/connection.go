@@ -3,23 +3,86 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"net/url"
 	"os"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
 )
 
-func InitDB() *sql.DB {
+const (
+	DriverMySQL    = "mysql"
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+
+	defaultSQLitePath = "quiz_master.db"
+)
+
+// InitDB opens the database for the given driver name. An empty driver
+// falls back to DB_DRIVER and then to MySQL.
+func InitDB(driver string) *sql.DB {
 	var err error
-	db, err := sql.Open(os.Getenv("DB_DRIVER"),
-		os.Getenv("DB_USER")+":"+os.Getenv("DB_PASS")+"@tcp("+os.Getenv("DB_HOST")+":"+os.Getenv("DB_PORT")+")/"+os.Getenv("DB_NAME"))
+	var db *sql.DB
+	switch Driver(driver) {
+	case DriverSQLite:
+		db, err = sql.Open(SQLiteDriver, sqliteDSN())
+	case DriverPostgres:
+		db, err = sql.Open(DriverPostgres, postgresDSN())
+	default:
+		db, err = sql.Open(DriverMySQL,
+			os.Getenv("DB_USER")+":"+os.Getenv("DB_PASS")+"@tcp("+os.Getenv("DB_HOST")+":"+os.Getenv("DB_PORT")+")/"+os.Getenv("DB_NAME")+"?parseTime=true")
+	}
 	if err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, err)
 	}
 
 	err = db.Ping()
 
 	if err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, err)
 	}
 	return db
 }
+
+// Driver normalises a driver name, defaulting to DB_DRIVER and then MySQL.
+func Driver(driver string) string {
+	if driver == "" {
+		driver = os.Getenv("DB_DRIVER")
+	}
+	switch driver {
+	case DriverSQLite, "sqlite3":
+		return DriverSQLite
+	case DriverPostgres, "postgresql":
+		return DriverPostgres
+	default:
+		return DriverMySQL
+	}
+}
+
+// sqliteDSN points at the single file database, DB_PATH or ./quiz_master.db.
+// Transactions take the write lock as they begin, so that one reading before
+// it writes waits for the others instead of failing as busy.
+func sqliteDSN() string {
+	path := os.Getenv("DB_PATH")
+	if path == "" {
+		path = defaultSQLitePath
+	}
+	return "file:" + path + "?_foreign_keys=on&_busy_timeout=5000&_txlock=immediate"
+}
+
+// postgresDSN builds a lib/pq connection string from the DB_* variables,
+// DB_SSLMODE defaults to disable.
+func postgresDSN() string {
+	sslMode := os.Getenv("DB_SSLMODE")
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(os.Getenv("DB_USER"), os.Getenv("DB_PASS")),
+		Host:     os.Getenv("DB_HOST") + ":" + os.Getenv("DB_PORT"),
+		Path:     os.Getenv("DB_NAME"),
+		RawQuery: "sslmode=" + url.QueryEscape(sslMode),
+	}
+	return dsn.String()
+}
@@ -0,0 +1,297 @@
+package database
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations
+var migrationFiles embed.FS
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+  version BIGINT NOT NULL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  applied_at TIMESTAMP NOT NULL
+)`
+
+var (
+	// migrationFile matches e.g. 0002_add_answer_type.up.sql
+	migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+	statementEnd  = regexp.MustCompile(`;\s*(\n|$)`)
+	sqlComment    = regexp.MustCompile(`(?m)^\s*--.*$`)
+)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies the migrations embedded for one driver and keeps track of
+// them in the schema_migrations table.
+type Migrator struct {
+	db         *sql.DB
+	driver     string
+	migrations []Migration
+}
+
+func NewMigrator(db *sql.DB, driver string) (*Migrator, error) {
+	driver = Driver(driver)
+	dir, err := fs.Sub(migrationFiles, "migrations/"+driver)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := loadMigrations(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db, driver, migrations}, nil
+}
+
+func loadMigrations(dir fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(dir, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		match := migrationFile.FindStringSubmatch(e.Name())
+		if match == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(dir, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Status lists every known migration in order together with whether it has
+// been applied.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		at, ok := applied[mig.Version]
+		statuses = append(statuses, MigrationStatus{mig, ok, at})
+	}
+	return statuses, nil
+}
+
+// Pending returns the migrations that still have to be applied.
+func (m *Migrator) Pending() ([]Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []Migration{}
+	for _, s := range statuses {
+		if !s.Applied {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies every pending migration, oldest first, and returns the ones it
+// applied.
+func (m *Migrator) Up() ([]Migration, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, mig := range pending {
+		err := m.run(mig.Up,
+			"INSERT INTO schema_migrations(version, name, applied_at) VALUES(?, ?, ?)",
+			mig.Version, mig.Name, time.Now().UTC())
+		if err != nil {
+			return pending[:i], fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+	}
+	return pending, nil
+}
+
+// Down rolls back the most recently applied steps migrations, newest first,
+// and returns the ones it rolled back.
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	rolledBack := []Migration{}
+	for i := len(statuses) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+		mig := statuses[i].Migration
+		if !statuses[i].Applied {
+			continue
+		}
+		if mig.Down == "" {
+			return rolledBack, fmt.Errorf("migration %d_%s has no down script", mig.Version, mig.Name)
+		}
+		err := m.run(mig.Down, "DELETE FROM schema_migrations WHERE version = ?", mig.Version)
+		if err != nil {
+			return rolledBack, fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		rolledBack = append(rolledBack, mig)
+	}
+	return rolledBack, nil
+}
+
+// run executes a migration script and its bookkeeping statement in one
+// transaction. MySQL commits DDL implicitly, so there it is best effort.
+func (m *Migrator) run(script string, record string, args ...interface{}) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, stmt := range m.statements(script) {
+		if _, err := tx.Exec(stmt); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.Exec(m.rebind(record), args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *Migrator) applied() (map[int64]time.Time, error) {
+	if _, err := m.db.Exec(createSchemaMigrations); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.Query("SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+// statements splits a script for drivers that only run one statement per
+// Exec. Postgres and SQLite take the whole script at once, which keeps
+// trigger bodies intact.
+func (m *Migrator) statements(script string) []string {
+	if m.driver != DriverMySQL {
+		return []string{script}
+	}
+
+	stmts := []string{}
+	for _, stmt := range statementEnd.Split(script, -1) {
+		if strings.TrimSpace(sqlComment.ReplaceAllString(stmt, "")) != "" {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts
+}
+
+func (m *Migrator) rebind(query string) string {
+	if m.driver != DriverPostgres {
+		return query
+	}
+	for n := 1; strings.Contains(query, "?"); n++ {
+		query = strings.Replace(query, "?", "$"+strconv.Itoa(n), 1)
+	}
+	return query
+}
+
+// CreateMigration writes empty up and down scripts for a new migration into
+// dir/<driver> for every driver, numbered after the newest one on disk. The
+// files are embedded at build time, so the binary has to be rebuilt.
+func CreateMigration(dir, name string) ([]string, error) {
+	name = strings.Trim(strings.ToLower(regexp.MustCompile(`\W+`).ReplaceAllString(name, "_")), "_")
+	if name == "" {
+		return nil, fmt.Errorf("migration name is required")
+	}
+
+	drivers := []string{DriverMySQL, DriverPostgres, DriverSQLite}
+	var version int64
+	for _, driver := range drivers {
+		migrations, err := loadMigrations(os.DirFS(filepath.Join(dir, driver)))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if n := len(migrations); n > 0 && migrations[n-1].Version > version {
+			version = migrations[n-1].Version
+		}
+	}
+	version++
+
+	files := []string{}
+	for _, driver := range drivers {
+		if err := os.MkdirAll(filepath.Join(dir, driver), 0755); err != nil {
+			return files, err
+		}
+		for _, direction := range []string{"up", "down"} {
+			path := filepath.Join(dir, driver, fmt.Sprintf("%04d_%s.%s.sql", version, name, direction))
+			if err := os.WriteFile(path, []byte("-- "+direction+" migration for "+driver+"\n"), 0644); err != nil {
+				return files, err
+			}
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
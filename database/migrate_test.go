@@ -0,0 +1,130 @@
+package database
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSQLiteMigrator(t *testing.T) (*Migrator, *sql.DB) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := NewMigrator(db, DriverSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, db
+}
+
+func TestEmbeddedMigrationsMatchAcrossDrivers(t *testing.T) {
+	var versions [][]int64
+	for _, driver := range []string{DriverMySQL, DriverPostgres, DriverSQLite} {
+		m, err := NewMigrator(nil, driver)
+		assert.NoError(t, err)
+
+		v := []int64{}
+		for _, mig := range m.migrations {
+			assert.NotEmpty(t, mig.Down, "%s %d_%s", driver, mig.Version, mig.Name)
+			v = append(v, mig.Version)
+		}
+		versions = append(versions, v)
+	}
+	assert.Equal(t, versions[0], versions[1])
+	assert.Equal(t, versions[0], versions[2])
+}
+
+func TestMigrator_UpStatusDown(t *testing.T) {
+	m, db := newSQLiteMigrator(t)
+
+	pending, err := m.Pending()
+	assert.NoError(t, err)
+	assert.Len(t, pending, len(m.migrations))
+
+	applied, err := m.Up()
+	assert.NoError(t, err)
+	assert.Equal(t, pending, applied)
+
+	_, err = db.Exec("SELECT id FROM questions")
+	assert.NoError(t, err)
+
+	applied, err = m.Up()
+	assert.NoError(t, err)
+	assert.Empty(t, applied)
+
+	statuses, err := m.Status()
+	assert.NoError(t, err)
+	for _, s := range statuses {
+		assert.True(t, s.Applied)
+		assert.False(t, s.AppliedAt.IsZero())
+	}
+
+	rolledBack, err := m.Down(len(m.migrations))
+	assert.NoError(t, err)
+	assert.Len(t, rolledBack, len(m.migrations))
+	assert.Equal(t, m.migrations[0], rolledBack[len(rolledBack)-1])
+
+	_, err = db.Exec("SELECT id FROM questions")
+	assert.Error(t, err)
+}
+
+func TestMigrator_FailedMigrationIsNotRecorded(t *testing.T) {
+	m, _ := newSQLiteMigrator(t)
+	m.migrations = []Migration{{Version: 1, Name: "broken", Up: "CREATE TABLE oops (", Down: "SELECT 1"}}
+
+	applied, err := m.Up()
+	assert.Error(t, err)
+	assert.Empty(t, applied)
+
+	pending, err := m.Pending()
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+}
+
+func TestLoadMigrations(t *testing.T) {
+	dir := fstest.MapFS{
+		"0002_second.up.sql":   {Data: []byte("up 2")},
+		"0002_second.down.sql": {Data: []byte("down 2")},
+		"0001_first.up.sql":    {Data: []byte("up 1")},
+		"README.md":            {Data: []byte("ignored")},
+	}
+	migrations, err := loadMigrations(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 1, Name: "first", Up: "up 1"},
+		{Version: 2, Name: "second", Up: "up 2", Down: "down 2"},
+	}, migrations)
+
+	_, err = loadMigrations(fstest.MapFS{"0001_first.down.sql": {Data: []byte("down 1")}})
+	assert.Error(t, err)
+}
+
+func TestMySQLStatements(t *testing.T) {
+	m := &Migrator{driver: DriverMySQL}
+	stmts := m.statements("-- comment\nCREATE TABLE a (id int);\nCREATE TABLE b (id int);\n")
+	assert.Equal(t, []string{"-- comment\nCREATE TABLE a (id int)", "CREATE TABLE b (id int)"}, stmts)
+
+	assert.Empty(t, m.statements("-- up migration for mysql\n"))
+}
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, DriverSQLite), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, DriverSQLite, "0003_existing.up.sql"), []byte("up"), 0644))
+
+	files, err := CreateMigration(dir, "Add answer type")
+	assert.NoError(t, err)
+	assert.Len(t, files, 6)
+	assert.Contains(t, files, filepath.Join(dir, DriverPostgres, "0004_add_answer_type.up.sql"))
+
+	_, err = CreateMigration(dir, " - ")
+	assert.Error(t, err)
+}
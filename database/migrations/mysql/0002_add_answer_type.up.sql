@@ -0,0 +1 @@
+ALTER TABLE `questions` ADD COLUMN `answer_type` varchar(20) NOT NULL DEFAULT 'numeric' AFTER `answer`;
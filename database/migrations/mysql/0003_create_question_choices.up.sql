@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `question_choices` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `question_id` bigint unsigned NOT NULL,
+  `label` varchar(10) NOT NULL,
+  `content` varchar(255) NOT NULL,
+  `correct` tinyint(1) NOT NULL DEFAULT 0,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `question_choices_label` (`question_id`,`label`),
+  CONSTRAINT `question_choices_question` FOREIGN KEY (`question_id`) REFERENCES `questions` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,5 @@
+ALTER TABLE `questions`
+  ADD COLUMN `grading_rule` varchar(10) NOT NULL DEFAULT '' AFTER `lang`,
+  ADD COLUMN `grading_tolerance` varchar(50) NOT NULL DEFAULT '' AFTER `grading_rule`,
+  ADD COLUMN `grading_min` varchar(50) NOT NULL DEFAULT '' AFTER `grading_tolerance`,
+  ADD COLUMN `grading_max` varchar(50) NOT NULL DEFAULT '' AFTER `grading_min`;
@@ -0,0 +1 @@
+ALTER TABLE `questions` ADD COLUMN `fuzzy_threshold` int NOT NULL DEFAULT 0 AFTER `grading_max`;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `attempts` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `player` varchar(100) NOT NULL,
+  `question_number` varchar(100) NOT NULL,
+  `answer` varchar(255) NOT NULL,
+  `correct` tinyint(1) NOT NULL,
+  `score` double NOT NULL DEFAULT 0,
+  `answered_at` datetime NOT NULL,
+  `duration_ms` bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY (`id`),
+  KEY `attempts_player` (`player`, `answered_at`),
+  KEY `attempts_question_number` (`question_number`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
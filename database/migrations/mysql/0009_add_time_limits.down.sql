@@ -0,0 +1,2 @@
+ALTER TABLE `attempts` DROP COLUMN `timed_out`;
+ALTER TABLE `questions` DROP COLUMN `time_limit_ms`;
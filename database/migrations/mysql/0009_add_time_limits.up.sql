@@ -0,0 +1,2 @@
+ALTER TABLE `questions` ADD COLUMN `time_limit_ms` bigint NOT NULL DEFAULT 0 AFTER `fuzzy_threshold`;
+ALTER TABLE `attempts` ADD COLUMN `timed_out` tinyint(1) NOT NULL DEFAULT 0 AFTER `correct`;
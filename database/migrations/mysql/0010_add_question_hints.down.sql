@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `question_hints`;
+ALTER TABLE `questions` DROP COLUMN `explanation`;
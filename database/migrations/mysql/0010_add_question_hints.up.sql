@@ -0,0 +1,10 @@
+ALTER TABLE `questions` ADD COLUMN `explanation` varchar(1000) NOT NULL DEFAULT '' AFTER `time_limit_ms`;
+CREATE TABLE IF NOT EXISTS `question_hints` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `question_id` bigint unsigned NOT NULL,
+  `position` int NOT NULL,
+  `content` varchar(500) NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `question_hints_position` (`question_id`,`position`),
+  CONSTRAINT `question_hints_question` FOREIGN KEY (`question_id`) REFERENCES `questions` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
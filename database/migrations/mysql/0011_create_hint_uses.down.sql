@@ -0,0 +1,2 @@
+ALTER TABLE `attempts` DROP COLUMN `hints_used`;
+DROP TABLE IF EXISTS `hint_uses`;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `hint_uses` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `player` varchar(100) NOT NULL,
+  `question_number` varchar(100) NOT NULL,
+  `hint` int NOT NULL,
+  `used_at` datetime NOT NULL,
+  `attempt_id` bigint unsigned NULL,
+  PRIMARY KEY (`id`),
+  KEY `hint_uses_player` (`player`, `question_number`),
+  CONSTRAINT `hint_uses_attempt` FOREIGN KEY (`attempt_id`) REFERENCES `attempts` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+ALTER TABLE `attempts` ADD COLUMN `hints_used` int NOT NULL DEFAULT 0 AFTER `score`;
@@ -0,0 +1 @@
+ALTER TABLE `questions` ADD FULLTEXT INDEX `questions_search` (`question`,`answer`,`explanation`);
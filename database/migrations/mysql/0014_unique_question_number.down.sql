@@ -0,0 +1 @@
+ALTER TABLE `questions` DROP INDEX `questions_number`;
@@ -0,0 +1 @@
+ALTER TABLE `questions` ADD UNIQUE KEY `questions_number` (`number`);
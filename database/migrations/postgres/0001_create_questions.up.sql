@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS questions (
+  id bigserial PRIMARY KEY,
+  number varchar(100) DEFAULT NULL UNIQUE,
+  question varchar(100) DEFAULT NULL,
+  answer varchar(100) DEFAULT NULL,
+  created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  deleted_at timestamp DEFAULT NULL
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS question_choices (
+  id bigserial PRIMARY KEY,
+  question_id bigint NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+  label varchar(10) NOT NULL,
+  content varchar(255) NOT NULL,
+  correct boolean NOT NULL DEFAULT false,
+  UNIQUE (question_id, label)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS question_aliases;
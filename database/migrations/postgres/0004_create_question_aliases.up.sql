@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS question_aliases (
+  id bigserial PRIMARY KEY,
+  question_id bigint NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+  alias varchar(255) NOT NULL,
+  UNIQUE (question_id, alias)
+);
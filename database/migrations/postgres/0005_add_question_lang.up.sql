@@ -0,0 +1 @@
+ALTER TABLE questions ADD COLUMN lang varchar(5) NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+ALTER TABLE questions
+  DROP COLUMN grading_rule,
+  DROP COLUMN grading_tolerance,
+  DROP COLUMN grading_min,
+  DROP COLUMN grading_max;
@@ -0,0 +1 @@
+ALTER TABLE questions ADD COLUMN fuzzy_threshold integer NOT NULL DEFAULT 0;
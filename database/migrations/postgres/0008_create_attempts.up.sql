@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS attempts (
+  id bigserial PRIMARY KEY,
+  player varchar(100) NOT NULL,
+  question_number varchar(100) NOT NULL,
+  answer varchar(255) NOT NULL,
+  correct boolean NOT NULL,
+  score double precision NOT NULL DEFAULT 0,
+  answered_at timestamp NOT NULL,
+  duration_ms bigint NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS attempts_player ON attempts (player, answered_at);
+CREATE INDEX IF NOT EXISTS attempts_question_number ON attempts (question_number);
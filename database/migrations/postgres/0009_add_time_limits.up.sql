@@ -0,0 +1,2 @@
+ALTER TABLE questions ADD COLUMN time_limit_ms bigint NOT NULL DEFAULT 0;
+ALTER TABLE attempts ADD COLUMN timed_out boolean NOT NULL DEFAULT false;
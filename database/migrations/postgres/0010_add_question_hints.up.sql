@@ -0,0 +1,8 @@
+ALTER TABLE questions ADD COLUMN explanation varchar(1000) NOT NULL DEFAULT '';
+CREATE TABLE IF NOT EXISTS question_hints (
+  id bigserial PRIMARY KEY,
+  question_id bigint NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+  position integer NOT NULL,
+  content varchar(500) NOT NULL,
+  UNIQUE (question_id, position)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS hint_uses (
+  id bigserial PRIMARY KEY,
+  player varchar(100) NOT NULL,
+  question_number varchar(100) NOT NULL,
+  hint integer NOT NULL,
+  used_at timestamp NOT NULL,
+  attempt_id bigint REFERENCES attempts (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS hint_uses_player ON hint_uses (player, question_number);
+ALTER TABLE attempts ADD COLUMN hints_used integer NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE questions ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+  to_tsvector('simple', coalesce(question, '') || ' ' || coalesce(answer, '') || ' ' || explanation)
+) STORED;
+CREATE INDEX IF NOT EXISTS questions_search ON questions USING GIN (search_vector);
@@ -0,0 +1,2 @@
+-- the constraint belongs to 0001_create_questions, which drops it.
+SELECT 1;
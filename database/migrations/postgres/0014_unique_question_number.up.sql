@@ -0,0 +1,2 @@
+-- questions.number has been UNIQUE on Postgres since 0001_create_questions.
+SELECT 1;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS questions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  number VARCHAR(100) DEFAULT NULL,
+  question VARCHAR(100) DEFAULT NULL,
+  answer VARCHAR(100) DEFAULT NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  deleted_at DATETIME DEFAULT NULL
+);
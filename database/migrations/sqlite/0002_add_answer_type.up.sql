@@ -0,0 +1 @@
+ALTER TABLE questions ADD COLUMN answer_type VARCHAR(20) NOT NULL DEFAULT 'numeric';
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS question_choices (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  question_id INTEGER NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+  label VARCHAR(10) NOT NULL,
+  content VARCHAR(255) NOT NULL,
+  correct BOOLEAN NOT NULL DEFAULT 0,
+  UNIQUE (question_id, label)
+);
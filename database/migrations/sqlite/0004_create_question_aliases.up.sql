@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS question_aliases (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  question_id INTEGER NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+  alias VARCHAR(255) NOT NULL,
+  UNIQUE (question_id, alias)
+);
@@ -0,0 +1 @@
+ALTER TABLE questions ADD COLUMN lang VARCHAR(5) NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+ALTER TABLE questions DROP COLUMN grading_max;
+ALTER TABLE questions DROP COLUMN grading_min;
+ALTER TABLE questions DROP COLUMN grading_tolerance;
+ALTER TABLE questions DROP COLUMN grading_rule;
@@ -0,0 +1,4 @@
+ALTER TABLE questions ADD COLUMN grading_rule VARCHAR(10) NOT NULL DEFAULT '';
+ALTER TABLE questions ADD COLUMN grading_tolerance VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE questions ADD COLUMN grading_min VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE questions ADD COLUMN grading_max VARCHAR(50) NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE questions ADD COLUMN fuzzy_threshold INTEGER NOT NULL DEFAULT 0;
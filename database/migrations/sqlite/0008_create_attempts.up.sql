@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS attempts (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  player VARCHAR(100) NOT NULL,
+  question_number VARCHAR(100) NOT NULL,
+  answer VARCHAR(255) NOT NULL,
+  correct BOOLEAN NOT NULL,
+  score REAL NOT NULL DEFAULT 0,
+  answered_at DATETIME NOT NULL,
+  duration_ms INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS attempts_player ON attempts (player, answered_at);
+CREATE INDEX IF NOT EXISTS attempts_question_number ON attempts (question_number);
@@ -0,0 +1,2 @@
+ALTER TABLE questions ADD COLUMN time_limit_ms INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE attempts ADD COLUMN timed_out BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+ALTER TABLE questions ADD COLUMN explanation VARCHAR(1000) NOT NULL DEFAULT '';
+CREATE TABLE IF NOT EXISTS question_hints (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  question_id INTEGER NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+  position INTEGER NOT NULL,
+  content VARCHAR(500) NOT NULL,
+  UNIQUE (question_id, position)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS hint_uses (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  player VARCHAR(100) NOT NULL,
+  question_number VARCHAR(100) NOT NULL,
+  hint INTEGER NOT NULL,
+  used_at DATETIME NOT NULL,
+  attempt_id INTEGER REFERENCES attempts (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS hint_uses_player ON hint_uses (player, question_number);
+ALTER TABLE attempts ADD COLUMN hints_used INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS tags (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name VARCHAR(50) NOT NULL UNIQUE
+);
+CREATE TABLE IF NOT EXISTS question_tags (
+  question_id INTEGER NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+  tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+  PRIMARY KEY (question_id, tag_id)
+);
+CREATE INDEX IF NOT EXISTS question_tags_tag ON question_tags (tag_id);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS questions_fts_after_insert;
+DROP TRIGGER IF EXISTS questions_fts_after_update;
+DROP TRIGGER IF EXISTS questions_fts_before_delete;
+DROP TRIGGER IF EXISTS questions_fts_before_update;
+DROP TABLE IF EXISTS questions_fts;
@@ -0,0 +1,14 @@
+CREATE VIRTUAL TABLE IF NOT EXISTS questions_fts USING fts4(content="questions", question, answer, explanation);
+INSERT INTO questions_fts(questions_fts) VALUES('rebuild');
+CREATE TRIGGER IF NOT EXISTS questions_fts_before_update BEFORE UPDATE ON questions BEGIN
+  DELETE FROM questions_fts WHERE docid = old.id;
+END;
+CREATE TRIGGER IF NOT EXISTS questions_fts_before_delete BEFORE DELETE ON questions BEGIN
+  DELETE FROM questions_fts WHERE docid = old.id;
+END;
+CREATE TRIGGER IF NOT EXISTS questions_fts_after_update AFTER UPDATE ON questions BEGIN
+  INSERT INTO questions_fts(docid, question, answer, explanation) VALUES(new.id, new.question, new.answer, new.explanation);
+END;
+CREATE TRIGGER IF NOT EXISTS questions_fts_after_insert AFTER INSERT ON questions BEGIN
+  INSERT INTO questions_fts(docid, question, answer, explanation) VALUES(new.id, new.question, new.answer, new.explanation);
+END;
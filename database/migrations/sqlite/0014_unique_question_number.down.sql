@@ -0,0 +1 @@
+DROP INDEX IF EXISTS questions_number;
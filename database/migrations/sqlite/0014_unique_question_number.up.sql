@@ -0,0 +1 @@
+CREATE UNIQUE INDEX IF NOT EXISTS questions_number ON questions (number);
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"math"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// SQLiteDriver is the sqlite3 driver with okapi_bm25 registered on every
+// connection, the SQL function ranking full text search hits.
+const SQLiteDriver = "sqlite3_quiz_master"
+
+func init() {
+	sql.Register(SQLiteDriver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("okapi_bm25", okapiBM25, true)
+		},
+	})
+}
+
+// BM25 parameters, the usual ones: k1 for how soon more occurrences of a
+// word stop counting, b for how much longer text is held against a match.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// okapiBM25 scores a row of an FTS4 table from matchinfo(table, 'pcnalx'),
+// a higher score being a better match. Every column counts, each against
+// its own average length.
+func okapiBM25(info []byte) float64 {
+	if len(info) < 12 {
+		return 0
+	}
+	value := func(i int) float64 {
+		return float64(binary.NativeEndian.Uint32(info[4*i:]))
+	}
+	phrases, columns := int(value(0)), int(value(1))
+	if len(info) < 4*(3+2*columns+3*phrases*columns) {
+		return 0
+	}
+	rows := value(2)
+	average, length, hits := 3, 3+columns, 3+2*columns
+
+	score := 0.0
+	for p := 0; p < phrases; p++ {
+		for c := 0; c < columns; c++ {
+			x := hits + 3*(p*columns+c)
+			tf, matching := value(x), value(x+2)
+			if tf == 0 {
+				continue
+			}
+			idf := math.Log(1 + (rows-matching+0.5)/(matching+0.5))
+			norm := 1.0
+			if avg := value(average + c); avg > 0 {
+				norm = 1 - bm25B + bm25B*value(length+c)/avg
+			}
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+	}
+	return score
+}
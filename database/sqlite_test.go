@@ -0,0 +1,48 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteDriver_OkapiBM25(t *testing.T) {
+	db, err := sql.Open(SQLiteDriver, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+
+	_, err = db.Exec("CREATE VIRTUAL TABLE docs USING fts4(title, body)")
+	assert.NoError(t, err)
+	for _, doc := range [][]string{
+		{"Capital of France?", "Paris is the capital, Paris has been since 987."},
+		{"River through Paris?", "The Seine runs 777 kilometres from Burgundy to the English Channel at Le Havre."},
+		{"Capital of Italy?", "Rome"},
+	} {
+		_, err = db.Exec("INSERT INTO docs(title, body) VALUES(?, ?)", doc[0], doc[1])
+		assert.NoError(t, err)
+	}
+
+	rows, err := db.Query("SELECT docid, okapi_bm25(matchinfo(docs, 'pcnalx')) AS score FROM docs WHERE docs MATCH 'paris' ORDER BY score DESC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var ids []int
+	var scores []float64
+	for rows.Next() {
+		var id int
+		var score float64
+		assert.NoError(t, rows.Scan(&id, &score))
+		ids = append(ids, id)
+		scores = append(scores, score)
+	}
+	assert.NoError(t, rows.Err())
+	assert.Equal(t, []int{1, 2}, ids)
+	assert.Greater(t, scores[1], 0.0)
+
+	assert.Equal(t, 0.0, okapiBM25(nil))
+}
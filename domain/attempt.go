@@ -0,0 +1,101 @@
+package domain
+
+import "time"
+
+type AttemptRepository interface {
+	Store(attempt *Attempt) error
+	Fetch(filter AttemptFilter) ([]*Attempt, error)
+	ScoreByQuestion(player string) ([]QuestionScore, error)
+	Leaderboard(query LeaderboardQuery) ([]LeaderboardEntry, error)
+	StoreHintUse(use *HintUse) error
+	PendingHints(player string, questionNumber string) (int, error)
+}
+
+type AttemptUsecase interface {
+	Record(attempt *Attempt) error
+	History(filter AttemptFilter) ([]*Attempt, error)
+	Score(player string) (PlayerScore, error)
+	Leaderboard(by string, since time.Duration, limit int) ([]LeaderboardEntry, error)
+	UseHint(player string, question Question) (HintUse, error)
+}
+
+const (
+	LeaderboardByAccuracy = "accuracy"
+	LeaderboardByPoints   = "points"
+	LeaderboardByStreak   = "streak"
+)
+
+// Attempt is one answer a player gave to a question. It keeps the question
+// number rather than the question, so history survives the question being
+// renumbered or purged. TimedOut marks an answer given after the time
+// limit, which never counts as correct. HintsUsed counts the hints the
+// player was shown before answering.
+type Attempt struct {
+	ID             int           `json:"id"`
+	Player         string        `json:"player" validate:"required,max=100"`
+	QuestionNumber string        `json:"question_number" validate:"required"`
+	Answer         string        `json:"answer"`
+	Correct        bool          `json:"correct"`
+	TimedOut       bool          `json:"timed_out,omitempty"`
+	Score          float64       `json:"score"`
+	HintsUsed      int           `json:"hints_used,omitempty"`
+	AnsweredAt     time.Time     `json:"answered_at"`
+	Duration       time.Duration `json:"duration"`
+}
+
+// HintUse records that a player was shown hint number Hint, counting from
+// 1, of a question, out of Total hints, Content being the hint itself. It
+// counts against the player's next attempt at the question.
+type HintUse struct {
+	ID             int       `json:"id"`
+	Player         string    `json:"player" validate:"required,max=100"`
+	QuestionNumber string    `json:"question_number" validate:"required"`
+	Hint           int       `json:"hint"`
+	Content        string    `json:"content"`
+	Total          int       `json:"total"`
+	UsedAt         time.Time `json:"used_at"`
+}
+
+// AttemptFilter narrows the history down to a player, a question or both,
+// empty fields match everything.
+type AttemptFilter struct {
+	Player         string
+	QuestionNumber string
+}
+
+// QuestionScore sums up the attempts of a player at one question.
+type QuestionScore struct {
+	QuestionNumber string  `json:"question_number"`
+	Attempts       int     `json:"attempts"`
+	Correct        int     `json:"correct"`
+	Score          float64 `json:"score"`
+}
+
+// PlayerScore sums up every attempt of a player.
+type PlayerScore struct {
+	Player    string          `json:"player"`
+	Attempts  int             `json:"attempts"`
+	Correct   int             `json:"correct"`
+	Score     float64         `json:"score"`
+	Questions []QuestionScore `json:"questions"`
+}
+
+// LeaderboardQuery asks for the best Limit players by By, counting the
+// attempts answered at Since or later, all of them if Since is zero.
+type LeaderboardQuery struct {
+	By    string
+	Since time.Time
+	Limit int
+}
+
+// LeaderboardEntry is the standing of a player. Streak is the longest run of
+// correct answers in a row.
+type LeaderboardEntry struct {
+	Rank     int     `json:"rank"`
+	Player   string  `json:"player"`
+	Attempts int     `json:"attempts"`
+	Correct  int     `json:"correct"`
+	Accuracy float64 `json:"accuracy"`
+	Points   float64 `json:"points"`
+	Streak   int     `json:"streak"`
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kinds of failure, told apart with errors.Is whatever the message of the
+// error returned. ErrStorage is any other failure of the database, and
+// ErrUnavailable the database not being reachable at all.
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrValidation    = errors.New("validation failed")
+	ErrWrongAnswer   = errors.New("wrong answer")
+	ErrStorage       = errors.New("storage failure")
+	ErrUnavailable   = errors.New("database unavailable")
+)
+
+// Error is an error of Kind, one of the kinds above, shown to the user with
+// the message of Err.
+type Error struct {
+	Kind error
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// NewError marks err as of kind.
+func NewError(kind error, err error) error {
+	return &Error{Kind: kind, Err: err}
+}
+
+// Errorf formats an error of kind, %w wrapping as in fmt.Errorf.
+func Errorf(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// FieldError is what is wrong with one field of the input.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is an ErrValidation listing the fields at fault, its
+// message being theirs one per line.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		messages[i] = f.Message
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Invalid formats a ValidationError about a single field.
+func Invalid(field string, format string, args ...interface{}) error {
+	return &ValidationError{Fields: []FieldError{{Field: field, Message: fmt.Sprintf(format, args...)}}}
+}
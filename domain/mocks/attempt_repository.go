@@ -0,0 +1,123 @@
+package mocks
+
+import (
+	"quiz_master/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+type AttemptRepository struct {
+	mock.Mock
+}
+
+func (m *AttemptRepository) Store(attempt *domain.Attempt) error {
+	ret := m.Called(attempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Attempt) error); ok {
+		r0 = rf(attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *AttemptRepository) Fetch(filter domain.AttemptFilter) ([]*domain.Attempt, error) {
+	ret := m.Called(filter)
+
+	var r0 []*domain.Attempt
+	if rf, ok := ret.Get(0).(func(domain.AttemptFilter) []*domain.Attempt); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Attempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(domain.AttemptFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttemptRepository) ScoreByQuestion(player string) ([]domain.QuestionScore, error) {
+	ret := m.Called(player)
+
+	var r0 []domain.QuestionScore
+	if rf, ok := ret.Get(0).(func(string) []domain.QuestionScore); ok {
+		r0 = rf(player)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.QuestionScore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(player)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttemptRepository) Leaderboard(query domain.LeaderboardQuery) ([]domain.LeaderboardEntry, error) {
+	ret := m.Called(query)
+
+	var r0 []domain.LeaderboardEntry
+	if rf, ok := ret.Get(0).(func(domain.LeaderboardQuery) []domain.LeaderboardEntry); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LeaderboardEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(domain.LeaderboardQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttemptRepository) StoreHintUse(use *domain.HintUse) error {
+	ret := m.Called(use)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.HintUse) error); ok {
+		r0 = rf(use)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *AttemptRepository) PendingHints(player string, questionNumber string) (int, error) {
+	ret := m.Called(player, questionNumber)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string, string) int); ok {
+		r0 = rf(player, questionNumber)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(player, questionNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
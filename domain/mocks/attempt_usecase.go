@@ -0,0 +1,109 @@
+package mocks
+
+import (
+	"quiz_master/domain"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+type AttemptUsecase struct {
+	mock.Mock
+}
+
+func (m *AttemptUsecase) Record(attempt *domain.Attempt) error {
+	ret := m.Called(attempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Attempt) error); ok {
+		r0 = rf(attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *AttemptUsecase) History(filter domain.AttemptFilter) ([]*domain.Attempt, error) {
+	ret := m.Called(filter)
+
+	var r0 []*domain.Attempt
+	if rf, ok := ret.Get(0).(func(domain.AttemptFilter) []*domain.Attempt); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Attempt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(domain.AttemptFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttemptUsecase) Score(player string) (domain.PlayerScore, error) {
+	ret := m.Called(player)
+
+	var r0 domain.PlayerScore
+	if rf, ok := ret.Get(0).(func(string) domain.PlayerScore); ok {
+		r0 = rf(player)
+	} else {
+		r0 = ret.Get(0).(domain.PlayerScore)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(player)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttemptUsecase) Leaderboard(by string, since time.Duration, limit int) ([]domain.LeaderboardEntry, error) {
+	ret := m.Called(by, since, limit)
+
+	var r0 []domain.LeaderboardEntry
+	if rf, ok := ret.Get(0).(func(string, time.Duration, int) []domain.LeaderboardEntry); ok {
+		r0 = rf(by, since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LeaderboardEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Duration, int) error); ok {
+		r1 = rf(by, since, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *AttemptUsecase) UseHint(player string, question domain.Question) (domain.HintUse, error) {
+	ret := m.Called(player, question)
+
+	var r0 domain.HintUse
+	if rf, ok := ret.Get(0).(func(string, domain.Question) domain.HintUse); ok {
+		r0 = rf(player, question)
+	} else {
+		r0 = ret.Get(0).(domain.HintUse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, domain.Question) error); ok {
+		r1 = rf(player, question)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"quiz_master/domain"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -10,12 +11,12 @@ type QuestionRepository struct {
 	mock.Mock
 }
 
-func (m *QuestionRepository) GetAll() ([]*domain.Question, error) {
-	ret := m.Called()
+func (m *QuestionRepository) GetAll(options domain.QuestionListOptions) ([]*domain.Question, error) {
+	ret := m.Called(options)
 
 	var r0 []*domain.Question
-	if rf, ok := ret.Get(0).(func() []*domain.Question); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(domain.QuestionListOptions) []*domain.Question); ok {
+		r0 = rf(options)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Question)
@@ -23,8 +24,28 @@ func (m *QuestionRepository) GetAll() ([]*domain.Question, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(domain.QuestionListOptions) error); ok {
+		r1 = rf(options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *QuestionRepository) Count(filter domain.QuestionFilter) (int, error) {
+	ret := m.Called(filter)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(domain.QuestionFilter) int); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(domain.QuestionFilter) error); ok {
+		r1 = rf(filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -65,6 +86,26 @@ func (m *QuestionRepository) Store(q *domain.Question) error {
 	return r0
 }
 
+func (m *QuestionRepository) Upsert(q *domain.Question) (bool, error) {
+	ret := m.Called(q)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*domain.Question) bool); ok {
+		r0 = rf(q)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*domain.Question) error); ok {
+		r1 = rf(q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 func (m *QuestionRepository) Destroy(number string) error {
 	ret := m.Called(number)
 
@@ -77,3 +118,187 @@ func (m *QuestionRepository) Destroy(number string) error {
 
 	return r0
 }
+
+func (m *QuestionRepository) GetTrashed() ([]*domain.Question, error) {
+	ret := m.Called()
+
+	var r0 []*domain.Question
+	if rf, ok := ret.Get(0).(func() []*domain.Question); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Question)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *QuestionRepository) GetTrashedByNumber(number string) (domain.Question, error) {
+	ret := m.Called(number)
+
+	var r0 domain.Question
+	if rf, ok := ret.Get(0).(func(string) domain.Question); ok {
+		r0 = rf(number)
+	} else {
+		r0 = ret.Get(0).(domain.Question)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *QuestionRepository) Restore(number string) error {
+	ret := m.Called(number)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(number)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *QuestionRepository) Purge(deletedBefore time.Time) (int64, error) {
+	ret := m.Called(deletedBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *QuestionRepository) Update(q *domain.Question) error {
+	ret := m.Called(q)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Question) error); ok {
+		r0 = rf(q)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *QuestionRepository) AddAlias(questionID int, alias string) error {
+	ret := m.Called(questionID, alias)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(questionID, alias)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *QuestionRepository) RemoveAlias(questionID int, alias string) error {
+	ret := m.Called(questionID, alias)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string) error); ok {
+		r0 = rf(questionID, alias)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *QuestionRepository) AddTags(questionID int, tags []string) error {
+	ret := m.Called(questionID, tags)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []string) error); ok {
+		r0 = rf(questionID, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *QuestionRepository) RemoveTags(questionID int, tags []string) error {
+	ret := m.Called(questionID, tags)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []string) error); ok {
+		r0 = rf(questionID, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *QuestionRepository) CountTags() ([]domain.TagCount, error) {
+	ret := m.Called()
+
+	var r0 []domain.TagCount
+	if rf, ok := ret.Get(0).(func() []domain.TagCount); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TagCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *QuestionRepository) Search(query domain.SearchQuery) ([]domain.SearchResult, error) {
+	ret := m.Called(query)
+
+	var r0 []domain.SearchResult
+	if rf, ok := ret.Get(0).(func(domain.SearchQuery) []domain.SearchResult); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(domain.SearchQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
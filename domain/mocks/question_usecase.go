@@ -2,6 +2,8 @@ package mocks
 
 import (
 	"quiz_master/domain"
+	"quiz_master/dto"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -10,12 +12,12 @@ type QuestionUsecase struct {
 	mock.Mock
 }
 
-func (m *QuestionUsecase) GetAll() ([]*domain.Question, error) {
-	ret := m.Called()
+func (m *QuestionUsecase) GetAll(filter domain.QuestionFilter) ([]*domain.Question, error) {
+	ret := m.Called(filter)
 
 	var r0 []*domain.Question
-	if rf, ok := ret.Get(0).(func() []*domain.Question); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(domain.QuestionFilter) []*domain.Question); ok {
+		r0 = rf(filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Question)
@@ -23,8 +25,28 @@ func (m *QuestionUsecase) GetAll() ([]*domain.Question, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(domain.QuestionFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *QuestionUsecase) List(options domain.QuestionListOptions) (domain.QuestionPage, error) {
+	ret := m.Called(options)
+
+	var r0 domain.QuestionPage
+	if rf, ok := ret.Get(0).(func(domain.QuestionListOptions) domain.QuestionPage); ok {
+		r0 = rf(options)
+	} else {
+		r0 = ret.Get(0).(domain.QuestionPage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(domain.QuestionListOptions) error); ok {
+		r1 = rf(options)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -52,12 +74,12 @@ func (m *QuestionUsecase) GetByNumber(number string) (domain.Question, error) {
 	return r0, r1
 }
 
-func (m *QuestionUsecase) Store(args []string) error {
-	ret := m.Called(args)
+func (m *QuestionUsecase) Store(q *domain.Question) error {
+	ret := m.Called(q)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func([]string) error); ok {
-		r0 = rf(args)
+	if rf, ok := ret.Get(0).(func(*domain.Question) error); ok {
+		r0 = rf(q)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -65,6 +87,26 @@ func (m *QuestionUsecase) Store(args []string) error {
 	return r0
 }
 
+func (m *QuestionUsecase) Upsert(q *domain.Question) (bool, error) {
+	ret := m.Called(q)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*domain.Question) bool); ok {
+		r0 = rf(q)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*domain.Question) error); ok {
+		r1 = rf(q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 func (m *QuestionUsecase) Destroy(number string) error {
 	ret := m.Called(number)
 
@@ -78,15 +120,204 @@ func (m *QuestionUsecase) Destroy(number string) error {
 	return r0
 }
 
-func (m *QuestionUsecase) AnswerQuestion(args []string) error {
-	ret := m.Called(args)
+func (m *QuestionUsecase) AnswerQuestion(number string, answer string) (domain.AnswerResult, error) {
+	ret := m.Called(number, answer)
+
+	var r0 domain.AnswerResult
+	if rf, ok := ret.Get(0).(func(string, string) domain.AnswerResult); ok {
+		r0 = rf(number, answer)
+	} else {
+		r0 = ret.Get(0).(domain.AnswerResult)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(number, answer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *QuestionUsecase) GetTrashed() ([]*domain.Question, error) {
+	ret := m.Called()
+
+	var r0 []*domain.Question
+	if rf, ok := ret.Get(0).(func() []*domain.Question); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Question)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *QuestionUsecase) Restore(number string) error {
+	ret := m.Called(number)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(number)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *QuestionUsecase) PurgeTrash(olderThan time.Duration) (int64, error) {
+	ret := m.Called(olderThan)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(time.Duration) int64); ok {
+		r0 = rf(olderThan)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Duration) error); ok {
+		r1 = rf(olderThan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *QuestionUsecase) Update(number string, request *dto.RequestUpdateQuestion) ([]domain.QuestionChange, error) {
+	ret := m.Called(number, request)
+
+	var r0 []domain.QuestionChange
+	if rf, ok := ret.Get(0).(func(string, *dto.RequestUpdateQuestion) []domain.QuestionChange); ok {
+		r0 = rf(number, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.QuestionChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *dto.RequestUpdateQuestion) error); ok {
+		r1 = rf(number, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *QuestionUsecase) AddAlias(number string, alias string) error {
+	ret := m.Called(number, alias)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(number, alias)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *QuestionUsecase) RemoveAlias(number string, alias string) error {
+	ret := m.Called(number, alias)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(number, alias)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+func (m *QuestionUsecase) TagQuestion(number string, tags []string) ([]string, error) {
+	ret := m.Called(number, tags)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, []string) []string); ok {
+		r0 = rf(number, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(number, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *QuestionUsecase) UntagQuestion(number string, tags []string) error {
+	ret := m.Called(number, tags)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func([]string) error); ok {
-		r0 = rf(args)
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(number, tags)
 	} else {
 		r0 = ret.Error(0)
 	}
 
 	return r0
 }
+
+func (m *QuestionUsecase) ListTags() ([]domain.TagCount, error) {
+	ret := m.Called()
+
+	var r0 []domain.TagCount
+	if rf, ok := ret.Get(0).(func() []domain.TagCount); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TagCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+func (m *QuestionUsecase) Search(query domain.SearchQuery) ([]domain.SearchResult, error) {
+	ret := m.Called(query)
+
+	var r0 []domain.SearchResult
+	if rf, ok := ret.Get(0).(func(domain.SearchQuery) []domain.SearchResult); ok {
+		r0 = rf(query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(domain.SearchQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
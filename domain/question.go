@@ -1,23 +1,187 @@
 package domain
 
+import (
+	"quiz_master/dto"
+	"time"
+)
+
+const (
+	AnswerTypeNumeric = "numeric"
+	AnswerTypeText    = "text"
+	AnswerTypeChoice  = "choice"
+)
+
+// Outcomes of AnswerResult. OutcomePartial is reserved for grading rules
+// giving partial credit, none does yet.
+const (
+	OutcomeCorrect   = "correct"
+	OutcomeIncorrect = "incorrect"
+	OutcomePartial   = "partial"
+)
+
+const (
+	SortNumber   = "number"
+	SortCreated  = "created"
+	SortQuestion = "question"
+)
+
+const (
+	GradingExact    = ""
+	GradingAbsolute = "absolute"
+	GradingRelative = "relative"
+	GradingRange    = "range"
+)
+
 type QuestionRepository interface {
-	GetAll() ([]*Question, error)
+	GetAll(options QuestionListOptions) ([]*Question, error)
+	Count(filter QuestionFilter) (int, error)
 	Store(question *Question) error
+	Upsert(question *Question) (bool, error)
 	GetByNumber(number string) (Question, error)
+	Update(question *Question) error
 	Destroy(number string) error
+	GetTrashed() ([]*Question, error)
+	GetTrashedByNumber(number string) (Question, error)
+	Restore(number string) error
+	Purge(deletedBefore time.Time) (int64, error)
+	AddAlias(questionID int, alias string) error
+	RemoveAlias(questionID int, alias string) error
+	AddTags(questionID int, tags []string) error
+	RemoveTags(questionID int, tags []string) error
+	CountTags() ([]TagCount, error)
+	Search(query SearchQuery) ([]SearchResult, error)
 }
 
 type QuestionUsecase interface {
-	Store(args []string) error
-	GetAll() ([]*Question, error)
+	Store(question *Question) error
+	Upsert(question *Question) (bool, error)
+	GetAll(filter QuestionFilter) ([]*Question, error)
+	List(options QuestionListOptions) (QuestionPage, error)
 	GetByNumber(number string) (Question, error)
-	AnswerQuestion(args []string) error
+	AnswerQuestion(number string, answer string) (AnswerResult, error)
+	Update(number string, request *dto.RequestUpdateQuestion) ([]QuestionChange, error)
 	Destroy(number string) error
+	GetTrashed() ([]*Question, error)
+	Restore(number string) error
+	PurgeTrash(olderThan time.Duration) (int64, error)
+	AddAlias(number string, alias string) error
+	RemoveAlias(number string, alias string) error
+	TagQuestion(number string, tags []string) ([]string, error)
+	UntagQuestion(number string, tags []string) error
+	ListTags() ([]TagCount, error)
+	Search(query SearchQuery) ([]SearchResult, error)
 }
 
+// Question is a quiz question. Fuzzy is how many typos a text answer may
+// have and TimeLimit how long answering it may take, zero for no limit.
+// Hints are revealed to a player one at a time, in order, and Explanation
+// is shown once an answer is graded. Tags group questions by topic.
 type Question struct {
-	ID       int    `json:"id"`
-	Number   string `json:"number" validate:"required,numeric"`
-	Question string `json:"question" validate:"required"`
-	Answer   string `json:"answer" validate:"required,numeric"`
+	ID          int           `json:"id"`
+	Number      string        `json:"number" validate:"required,numeric"`
+	Question    string        `json:"question" validate:"required"`
+	Answer      string        `json:"answer" validate:"required"`
+	AnswerType  string        `json:"answer_type" validate:"required,oneof=numeric text choice"`
+	Lang        string        `json:"lang,omitempty"`
+	Grading     Grading       `json:"grading"`
+	Fuzzy       int           `json:"fuzzy,omitempty" validate:"min=0"`
+	TimeLimit   time.Duration `json:"time_limit,omitempty" validate:"min=0"`
+	Choices     []Choice      `json:"choices,omitempty" validate:"dive"`
+	Aliases     []string      `json:"aliases,omitempty"`
+	Hints       []string      `json:"hints,omitempty" validate:"dive,required,max=500"`
+	Explanation string        `json:"explanation,omitempty" validate:"max=1000"`
+	Tags        []string      `json:"tags,omitempty"`
+	DeletedAt   *time.Time    `json:"deleted_at,omitempty"`
+}
+
+// QuestionFilter narrows down a list of questions. Tags keeps the questions
+// with any of them, all questions when empty.
+type QuestionFilter struct {
+	Tags []string
+}
+
+// QuestionListOptions picks a page of the questions matching Filter. Sort
+// is one of SortNumber, the default, SortCreated or SortQuestion, numbers
+// being compared as numbers, and Desc reverses it. Limit caps the page
+// size, zero for no limit, and Offset skips that many questions first.
+type QuestionListOptions struct {
+	Filter QuestionFilter
+	Sort   string
+	Desc   bool
+	Limit  int
+	Offset int
+}
+
+// QuestionPage is a page of questions listed with QuestionListOptions,
+// starting after Offset of the Total questions matching the filter.
+type QuestionPage struct {
+	Questions []*Question `json:"questions"`
+	Offset    int         `json:"offset"`
+	Total     int         `json:"total"`
+}
+
+// TagCount is a tag and how many questions have it.
+type TagCount struct {
+	Tag       string `json:"tag"`
+	Questions int    `json:"questions"`
+}
+
+// SearchQuery looks for the questions whose text, answer or explanation
+// contain every word of Terms, the trashed ones too if IncludeDeleted.
+// Limit caps the number of results, zero for all of them.
+type SearchQuery struct {
+	Terms          string
+	IncludeDeleted bool
+	Limit          int
+}
+
+// SearchResult is a question matching a SearchQuery. Score ranks it, higher
+// first, and Snippet is an extract with the matched words highlighted.
+// Question has no choices, aliases, hints or tags loaded.
+type SearchResult struct {
+	Question Question `json:"question"`
+	Score    float64  `json:"score"`
+	Snippet  string   `json:"snippet"`
+}
+
+// Grading is how far a numeric answer may be from the stored one: not at all
+// for GradingExact, Tolerance for GradingAbsolute, Tolerance percent of the
+// stored answer for GradingRelative, and between Min and Max inclusive for
+// GradingRange. Values are kept as written to stay exact.
+type Grading struct {
+	Rule      string `json:"rule,omitempty"`
+	Tolerance string `json:"tolerance,omitempty"`
+	Min       string `json:"min,omitempty"`
+	Max       string `json:"max,omitempty"`
+}
+
+// Choice is one lettered option of a multiple choice question.
+type Choice struct {
+	Label   string `json:"label" validate:"required,max=10"`
+	Text    string `json:"text" validate:"required"`
+	Correct bool   `json:"correct"`
+}
+
+// AnswerResult is the grade of an answer. Score runs from 0 for an
+// incorrect answer to 1 for a correct one, an OutcomePartial answer scoring
+// in between. Accepted is the accepted answer a correct answer matched, the
+// question's own answer or, if Alias, one of its aliases. Distance counts the typos of an answer that was only close,
+// accepted or not. Feedback is what to tell the player, in Lang, and
+// Explanation what the question explains once answered.
+type AnswerResult struct {
+	Outcome     string  `json:"outcome"`
+	Score       float64 `json:"score"`
+	Accepted    string  `json:"accepted,omitempty"`
+	Alias       bool    `json:"alias,omitempty"`
+	Distance    int     `json:"distance,omitempty"`
+	Feedback    string  `json:"feedback"`
+	Lang        string  `json:"lang"`
+	Explanation string  `json:"explanation,omitempty"`
+}
+
+// QuestionChange describes one field changed by QuestionUsecase.Update.
+type QuestionChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
 }
@@ -1,5 +1,33 @@
 package dto
 
+import "time"
+
 type RequestGetOrDeleteQuestion struct {
 	Number string `json:"number" validate:"numeric"`
 }
+
+// RequestUpdateQuestion holds the fields to change, nil fields are left as
+// they are.
+type RequestUpdateQuestion struct {
+	Number     *string `json:"number,omitempty"`
+	Question   *string `json:"question,omitempty"`
+	Answer     *string `json:"answer,omitempty"`
+	AnswerType *string `json:"answer_type,omitempty"`
+	Lang       *string `json:"lang,omitempty"`
+	// Grading replaces the grading rule of a numeric answer, see
+	// domain.Grading.
+	Grading *RequestGrading `json:"grading,omitempty"`
+	Fuzzy   *int            `json:"fuzzy,omitempty"`
+	// TimeLimit replaces the time limit, zero for none.
+	TimeLimit *time.Duration `json:"time_limit,omitempty"`
+	// Hints replaces all the hints, empty to remove them.
+	Hints       *[]string `json:"hints,omitempty"`
+	Explanation *string   `json:"explanation,omitempty"`
+}
+
+type RequestGrading struct {
+	Rule      string `json:"rule"`
+	Tolerance string `json:"tolerance,omitempty"`
+	Min       string `json:"min,omitempty"`
+	Max       string `json:"max,omitempty"`
+}
@@ -0,0 +1,47 @@
+package helper
+
+import (
+	"fmt"
+	"io"
+	"quiz_master/domain"
+	"strings"
+)
+
+// ParseChoices reads options given as "A=Paris" and flags the ones whose
+// labels are listed in correct.
+func ParseChoices(values []string, correct []string) ([]domain.Choice, error) {
+	choices := []domain.Choice{}
+	for _, v := range values {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, domain.Invalid("choice", "choice %q must look like \"A=Paris\"", v)
+		}
+		choices = append(choices, domain.Choice{
+			Label: strings.TrimSpace(parts[0]),
+			Text:  strings.TrimSpace(parts[1]),
+		})
+	}
+
+	for _, label := range correct {
+		label = strings.TrimSpace(label)
+		found := false
+		for i := range choices {
+			if strings.EqualFold(choices[i].Label, label) {
+				choices[i].Correct = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, domain.Invalid("correct", "Choice %s does not exist", label)
+		}
+	}
+	return choices, nil
+}
+
+// WriteChoices prints the options of a multiple choice question, one
+// lettered line each.
+func WriteChoices(w io.Writer, choices []domain.Choice) {
+	for _, c := range choices {
+		fmt.Fprintf(w, "    %s) %s\n", c.Label, c.Text)
+	}
+}
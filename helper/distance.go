@@ -0,0 +1,41 @@
+package helper
+
+// EditDistance counts the typos between a and b: characters inserted,
+// deleted or replaced, and neighbours swapped (the optimal string alignment
+// variant of the Damerau-Levenshtein distance).
+func EditDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	// rows of the distance matrix two, one and zero rows above the current
+	twoUp := make([]int, len(t)+1)
+	oneUp := make([]int, len(t)+1)
+	row := make([]int, len(t)+1)
+	for j := range oneUp {
+		oneUp[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		row[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			row[j] = minOf(oneUp[j]+1, row[j-1]+1, oneUp[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				row[j] = minOf(row[j], twoUp[j-2]+1)
+			}
+		}
+		twoUp, oneUp, row = oneUp, row, twoUp
+	}
+	return oneUp[len(t)]
+}
+
+func minOf(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
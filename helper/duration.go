@@ -0,0 +1,48 @@
+package helper
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var dayOrWeek = regexp.MustCompile(`(\d+(?:\.\d+)?)([dw])`)
+
+// ParseDuration extends time.ParseDuration with days and weeks, e.g. "30d"
+// or "1w12h".
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	var d time.Duration
+	for _, m := range dayOrWeek.FindAllStringSubmatch(s, -1) {
+		n, _ := strconv.ParseFloat(m[1], 64)
+		unit := 24 * time.Hour
+		if m[2] == "w" {
+			unit *= 7
+		}
+		d += time.Duration(n * float64(unit))
+	}
+
+	rest := dayOrWeek.ReplaceAllString(s, "")
+	if rest == "" {
+		return d, nil
+	}
+	extra, err := time.ParseDuration(rest)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d + extra, nil
+}
+
+// FormatTimeLimit shows a time limit, "none" when there is no limit.
+func FormatTimeLimit(d time.Duration) string {
+	if d <= 0 {
+		return "none"
+	}
+	return d.String()
+}
@@ -0,0 +1,29 @@
+package helper
+
+import (
+	"fmt"
+	"quiz_master/domain"
+	"strings"
+)
+
+// ParseRange reads an inclusive range given as "MIN..MAX".
+func ParseRange(value string) (string, string, error) {
+	parts := strings.SplitN(value, "..", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return "", "", domain.Invalid("range", "range %q must look like \"MIN..MAX\"", value)
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), nil
+}
+
+// FormatGrading describes a grading rule the way authors set it.
+func FormatGrading(g domain.Grading) string {
+	switch g.Rule {
+	case domain.GradingAbsolute:
+		return fmt.Sprintf("within ±%s", g.Tolerance)
+	case domain.GradingRelative:
+		return fmt.Sprintf("within ±%s%%", g.Tolerance)
+	case domain.GradingRange:
+		return fmt.Sprintf("between %s and %s", g.Min, g.Max)
+	}
+	return "exact"
+}
@@ -0,0 +1,65 @@
+package helper
+
+// Messages told to the player, MessagePartial being reserved for the
+// domain.OutcomePartial no grading rule gives yet.
+const (
+	MessageCorrect       = "correct"
+	MessageWrongAnswer   = "wrong_answer"
+	MessageCloseAccepted = "close_accepted"
+	MessageAlmost        = "almost"
+	MessagePartial       = "partial"
+	MessageTimedOut      = "timed_out"
+)
+
+// messages holds the translations of what the quiz tells the player, by
+// language and then by message.
+var messages = map[string]map[string]string{
+	"en": {
+		MessageCorrect:       "Correct!",
+		MessageWrongAnswer:   "Wrong Answer!",
+		MessageCloseAccepted: "Close, but accepted!",
+		MessageAlmost:        "Almost — check your spelling!",
+		MessagePartial:       "Partly correct!",
+		MessageTimedOut:      "Time's up!",
+	},
+	"id": {
+		MessageCorrect:       "Benar!",
+		MessageWrongAnswer:   "Jawaban Salah!",
+		MessageCloseAccepted: "Hampir tepat, diterima!",
+		MessageAlmost:        "Hampir — periksa ejaanmu!",
+		MessagePartial:       "Sebagian benar!",
+		MessageTimedOut:      "Waktu habis!",
+	},
+	"es": {
+		MessageCorrect:       "¡Correcto!",
+		MessageWrongAnswer:   "¡Respuesta incorrecta!",
+		MessageCloseAccepted: "¡Casi, pero aceptada!",
+		MessageAlmost:        "Casi — revisa la ortografía.",
+		MessagePartial:       "¡Parcialmente correcto!",
+		MessageTimedOut:      "¡Se acabó el tiempo!",
+	},
+	"fr": {
+		MessageCorrect:       "Correct !",
+		MessageWrongAnswer:   "Mauvaise réponse !",
+		MessageCloseAccepted: "Presque, mais acceptée !",
+		MessageAlmost:        "Presque — vérifiez l'orthographe !",
+		MessagePartial:       "Partiellement correct !",
+		MessageTimedOut:      "Temps écoulé !",
+	},
+	"de": {
+		MessageCorrect:       "Richtig!",
+		MessageWrongAnswer:   "Falsche Antwort!",
+		MessageCloseAccepted: "Knapp, aber akzeptiert!",
+		MessageAlmost:        "Fast — prüfe die Rechtschreibung!",
+		MessagePartial:       "Teilweise richtig!",
+		MessageTimedOut:      "Die Zeit ist um!",
+	},
+}
+
+// Message returns the message key in lang, falling back to English.
+func Message(lang string, key string) string {
+	if m, ok := messages[lang][key]; ok {
+		return m
+	}
+	return messages["en"][key]
+}
@@ -0,0 +1,69 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeOptions selects which differences between a given text answer and
+// the stored one are ignored.
+type NormalizeOptions struct {
+	Case        bool
+	Whitespace  bool
+	Punctuation bool
+	Diacritics  bool
+}
+
+// DefaultNormalizeOptions ignores everything that can be ignored.
+var DefaultNormalizeOptions = NormalizeOptions{Case: true, Whitespace: true, Punctuation: true, Diacritics: true}
+
+// ParseNormalizeOptions reads a list such as ["case", "whitespace"] from the
+// configuration, "none" turns every option off.
+func ParseNormalizeOptions(names []string) (NormalizeOptions, error) {
+	opts := NormalizeOptions{}
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "case":
+			opts.Case = true
+		case "whitespace":
+			opts.Whitespace = true
+		case "punctuation":
+			opts.Punctuation = true
+		case "diacritics":
+			opts.Diacritics = true
+		case "none", "":
+		default:
+			return opts, fmt.Errorf("unknown normalization %q", name)
+		}
+	}
+	return opts, nil
+}
+
+func NormalizeText(s string, opts NormalizeOptions) string {
+	if opts.Diacritics {
+		removeMarks := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+		if result, _, err := transform.String(removeMarks, s); err == nil {
+			s = result
+		}
+	}
+	if opts.Punctuation {
+		s = strings.Map(func(r rune) rune {
+			if unicode.IsPunct(r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+	if opts.Case {
+		s = strings.ToLower(s)
+	}
+	if opts.Whitespace {
+		s = strings.Join(strings.Fields(s), " ")
+	}
+	return s
+}
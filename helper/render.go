@@ -0,0 +1,179 @@
+package helper
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"quiz_master/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of the commands, table being the text meant for people.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatCSV   = "csv"
+)
+
+// Format is an output format usable as a flag value, it refuses the
+// formats it does not know.
+type Format string
+
+func (f *Format) String() string {
+	return string(*f)
+}
+
+func (f *Format) Set(value string) error {
+	switch value {
+	case FormatTable, FormatJSON, FormatYAML, FormatCSV:
+		*f = Format(value)
+		return nil
+	}
+	return fmt.Errorf("output must be one of %s, %s, %s or %s", FormatTable, FormatJSON, FormatYAML, FormatCSV)
+}
+
+func (f *Format) Type() string {
+	return "format"
+}
+
+// View is the result of a command in every format. Data is encoded as JSON
+// or YAML, with its json tags, Header and Rows are written as CSV, and Table
+// writes the text of the table format.
+type View struct {
+	Data   interface{}
+	Header []string
+	Rows   [][]string
+	Table  func(w io.Writer)
+}
+
+// TextView is a View for a command that only acknowledges what it did.
+func TextView(text string) View {
+	return View{
+		Data:   map[string]string{"message": text},
+		Header: []string{"message"},
+		Rows:   [][]string{{text}},
+		Table: func(w io.Writer) {
+			fmt.Fprintln(w, text)
+		},
+	}
+}
+
+// Renderer writes the Views of a command in format to w, and its errors in
+// the same format to log. Anything else the command prints, prompts, goes to
+// Log.
+type Renderer struct {
+	format Format
+	w      io.Writer
+	log    io.Writer
+}
+
+func NewRenderer(w io.Writer, log io.Writer, format Format) Renderer {
+	return Renderer{format: format, w: w, log: log}
+}
+
+// Log is where to write what is not part of the result: w itself for the
+// table format, log for the others so that w stays parseable.
+func (r Renderer) Log() io.Writer {
+	if r.format == FormatTable {
+		return r.w
+	}
+	return r.log
+}
+
+// Format is the output format of the renderer.
+func (r Renderer) Format() Format {
+	return r.format
+}
+
+func (r Renderer) Render(v View) {
+	r.render(r.w, v)
+}
+
+// ErrorOutput is how an error is encoded, with the fields at fault when it
+// is a domain.ValidationError.
+type ErrorOutput struct {
+	Error  string              `json:"error"`
+	Fields []domain.FieldError `json:"fields,omitempty"`
+}
+
+// Error renders err to log as an object with an error field, as a line of
+// text in the table format.
+func (r Renderer) Error(err error) {
+	data := ErrorOutput{Error: err.Error()}
+	var invalid *domain.ValidationError
+	if errors.As(err, &invalid) {
+		data.Fields = invalid.Fields
+	}
+	r.render(r.log, View{
+		Data:   data,
+		Header: []string{"error"},
+		Rows:   [][]string{{err.Error()}},
+		Table: func(w io.Writer) {
+			fmt.Fprintln(w, err.Error())
+		},
+	})
+}
+
+func (r Renderer) render(w io.Writer, v View) {
+	switch r.format {
+	case FormatJSON:
+		r.writeJSON(w, v.Data)
+	case FormatYAML:
+		r.writeYAML(w, v.Data)
+	case FormatCSV:
+		r.writeCSV(w, v.Header, v.Rows)
+	default:
+		v.Table(w)
+	}
+}
+
+func (r Renderer) writeJSON(w io.Writer, data interface{}) {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		fmt.Fprintln(r.log, err.Error())
+	}
+}
+
+// writeYAML goes through JSON so that YAML has the same field names, the
+// flow style of the parsed JSON being reset to block style.
+func (r Renderer) writeYAML(w io.Writer, data interface{}) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		fmt.Fprintln(r.log, err.Error())
+		return
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		fmt.Fprintln(r.log, err.Error())
+		return
+	}
+	blockStyle(&doc)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		fmt.Fprintln(r.log, err.Error())
+	}
+	encoder.Close()
+}
+
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		blockStyle(n)
+	}
+}
+
+func (r Renderer) writeCSV(w io.Writer, header []string, rows [][]string) {
+	writer := csv.NewWriter(w)
+	writer.Write(header)
+	writer.WriteAll(rows)
+	if err := writer.Error(); err != nil {
+		fmt.Fprintln(r.log, err.Error())
+	}
+}
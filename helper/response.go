@@ -2,13 +2,160 @@ package helper
 
 import (
 	"fmt"
+	"io"
+	"math"
 	"quiz_master/domain"
+	"strconv"
+	"strings"
 )
 
-func ListQuestionResponse(questions []*domain.Question) {
-	fmt.Println("No |\tQuestion\t|\tAnswer")
+func QuestionResponse(w io.Writer, question domain.Question) {
+	fmt.Fprintf(w, "Q : %s\n", question.Question)
+	WriteChoices(w, question.Choices)
+	fmt.Fprintf(w, "A : %s\n", question.Answer)
+	if question.Grading.Rule != domain.GradingExact {
+		fmt.Fprintf(w, "Grading : %s\n", FormatGrading(question.Grading))
+	}
+	if question.Fuzzy > 0 {
+		fmt.Fprintf(w, "Fuzzy : up to %d typos\n", question.Fuzzy)
+	}
+	if question.TimeLimit > 0 {
+		fmt.Fprintf(w, "Time limit : %s\n", question.TimeLimit)
+	}
+	for i, h := range question.Hints {
+		fmt.Fprintf(w, "Hint %d : %s\n", i+1, h)
+	}
+	if question.Explanation != "" {
+		fmt.Fprintf(w, "Explanation : %s\n", question.Explanation)
+	}
+	if len(question.Tags) > 0 {
+		fmt.Fprintf(w, "Tags : %s\n", strings.Join(question.Tags, ", "))
+	}
+	if len(question.Aliases) > 0 {
+		fmt.Fprintf(w, "Aliases : %s\n", strings.Join(question.Aliases, ", "))
+	}
+}
+
+func CreatedQuestionResponse(w io.Writer, q domain.Question) {
+	savedQuestionResponse(w, q, "created")
+}
+
+func ReplacedQuestionResponse(w io.Writer, q domain.Question) {
+	savedQuestionResponse(w, q, "replaced")
+}
+
+func savedQuestionResponse(w io.Writer, q domain.Question, verb string) {
+	fmt.Fprintf(w, "Question no %s %s :\nQ : %s\n", q.Number, verb, q.Question)
+	WriteChoices(w, q.Choices)
+	fmt.Fprintf(w, "A : %s\n", q.Answer)
+	if q.Grading.Rule != domain.GradingExact {
+		fmt.Fprintf(w, "Grading : %s\n", FormatGrading(q.Grading))
+	}
+}
+
+func ListQuestionResponse(w io.Writer, page domain.QuestionPage, perPage int) {
+	fmt.Fprintln(w, "No |\tQuestion\t|\tAnswer")
+	for _, q := range page.Questions {
+		fmt.Fprintf(w, "%s\t%s\t\t\t%s\n", q.Number, q.Question, q.Answer)
+		WriteChoices(w, q.Choices)
+	}
+	fmt.Fprintf(w, "\n")
+	fmt.Fprintln(w, PageFooter(page, perPage))
+}
+
+// PageFooter tells which of the questions matching a list page shows and,
+// with perPage questions a page, which page it is.
+func PageFooter(page domain.QuestionPage, perPage int) string {
+	shown := "0"
+	if len(page.Questions) > 0 {
+		shown = fmt.Sprintf("%d-%d", page.Offset+1, page.Offset+len(page.Questions))
+	}
+	footer := fmt.Sprintf("Questions %s of %d", shown, page.Total)
+	if perPage > 0 {
+		pages := (page.Total + perPage - 1) / perPage
+		if pages == 0 {
+			pages = 1
+		}
+		footer += fmt.Sprintf(", page %d of %d", page.Offset/perPage+1, pages)
+	}
+	return footer
+}
+
+func ListTagsResponse(w io.Writer, counts []domain.TagCount) {
+	fmt.Fprintln(w, "Tag |\tQuestions")
+	for _, c := range counts {
+		fmt.Fprintf(w, "%s\t%d\n", c.Tag, c.Questions)
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+func ListTrashResponse(w io.Writer, questions []*domain.Question) {
+	fmt.Fprintln(w, "No |\tQuestion\t|\tDeleted at")
 	for _, q := range questions {
-		fmt.Printf("%s\t%s\t\t\t%s\n", q.Number, q.Question, q.Answer)
+		fmt.Fprintf(w, "%s\t%s\t\t\t%s\n", q.Number, q.Question, q.DeletedAt.Format("2006-01-02 15:04:05"))
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+func SearchResponse(w io.Writer, results []domain.SearchResult) {
+	fmt.Fprintln(w, "No |\tScore\t|\tSnippet")
+	for _, r := range results {
+		snippet := r.Snippet
+		if r.Question.DeletedAt != nil {
+			snippet += " (deleted)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t\t%s\n", r.Question.Number, FormatScore(r.Score), snippet)
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+func HistoryResponse(w io.Writer, attempts []*domain.Attempt) {
+	fmt.Fprintln(w, "Answered at\t\tPlayer\tNo\tAnswer\tResult\tTime")
+	for _, a := range attempts {
+		result := "wrong"
+		if a.Correct {
+			result = "correct"
+		} else if a.TimedOut {
+			result = "timed out"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%q\t%s\t%s\n", a.AnsweredAt.Local().Format("2006-01-02 15:04:05"),
+			a.Player, a.QuestionNumber, a.Answer, result, a.Duration)
+	}
+	fmt.Fprintf(w, "\n")
+}
+
+func ScoreResponse(w io.Writer, score domain.PlayerScore) {
+	fmt.Fprintf(w, "Player : %s\n", score.Player)
+	fmt.Fprintf(w, "Attempts : %d\n", score.Attempts)
+	fmt.Fprintf(w, "Correct : %d (%s)\n", score.Correct, Percent(score.Correct, score.Attempts))
+	fmt.Fprintf(w, "Score : %s\n", FormatScore(score.Score))
+	if len(score.Questions) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nNo |\tAttempts\t|\tCorrect")
+	for _, q := range score.Questions {
+		fmt.Fprintf(w, "%s\t%d\t\t\t%d\n", q.QuestionNumber, q.Attempts, q.Correct)
+	}
+}
+
+func LeaderboardResponse(w io.Writer, entries []domain.LeaderboardEntry) {
+	fmt.Fprintln(w, "Rank |\tPlayer\t|\tPoints\t|\tAccuracy\t|\tStreak\t|\tAttempts")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%d\t%s\t\t%s\t\t%s\t\t\t%d\t\t%d\n", e.Rank, e.Player, FormatScore(e.Points),
+			Percent(e.Correct, e.Attempts), e.Streak, e.Attempts)
 	}
-	fmt.Printf("\n")
+	fmt.Fprintf(w, "\n")
+}
+
+// Percent formats part of total as a whole percentage, 0% of nothing.
+func Percent(part, total int) string {
+	if total == 0 {
+		return "0%"
+	}
+	return strconv.Itoa(int(math.Round(float64(part)*100/float64(total)))) + "%"
+}
+
+// FormatScore prints a score with at most two decimals.
+func FormatScore(score float64) string {
+	return strconv.FormatFloat(math.Round(score*100)/100, 'f', -1, 64)
 }
@@ -0,0 +1,38 @@
+package helper
+
+import (
+	"math/rand"
+	"quiz_master/domain"
+)
+
+// PickQuestions chooses the questions of a quiz session: the ones numbered
+// in numbers in that order, or else all of them. rnd shuffles them unless it
+// is nil, and count keeps only the first ones unless it is zero.
+func PickQuestions(questions []*domain.Question, numbers []string, count int, rnd *rand.Rand) ([]*domain.Question, error) {
+	picked := questions
+	if len(numbers) > 0 {
+		byNumber := map[string]*domain.Question{}
+		for _, q := range questions {
+			byNumber[q.Number] = q
+		}
+		picked = []*domain.Question{}
+		for _, n := range numbers {
+			q, ok := byNumber[n]
+			if !ok {
+				return nil, domain.Errorf(domain.ErrNotFound, "Question no %s not found", n)
+			}
+			picked = append(picked, q)
+		}
+	}
+
+	picked = append([]*domain.Question(nil), picked...)
+	if rnd != nil {
+		rnd.Shuffle(len(picked), func(i, j int) {
+			picked[i], picked[j] = picked[j], picked[i]
+		})
+	}
+	if count > 0 && count < len(picked) {
+		picked = picked[:count]
+	}
+	return picked, nil
+}
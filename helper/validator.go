@@ -1,7 +1,9 @@
 package helper
 
 import (
-	"errors"
+	"quiz_master/domain"
+	"strings"
+	"unicode"
 
 	"github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
@@ -10,8 +12,7 @@ import (
 )
 
 var (
-	uni           *ut.UniversalTranslator
-	errorMessages = ""
+	uni *ut.UniversalTranslator
 )
 
 func Validate(i interface{}) error {
@@ -24,13 +25,11 @@ func Validate(i interface{}) error {
 		en_translations.RegisterDefaultTranslations(newValidator, trans)
 		customMessage(trans, newValidator)
 		errs := err.(validator.ValidationErrors)
-		for i, e := range errs {
-			errorMessages += e.Translate(trans)
-			if i != len(errs)-1 {
-				errorMessages += "\n"
-			}
+		invalid := &domain.ValidationError{}
+		for _, e := range errs {
+			invalid.Fields = append(invalid.Fields, domain.FieldError{Field: snakeCase(e.StructField()), Message: e.Translate(trans)})
 		}
-		return errors.New(errorMessages)
+		return invalid
 	}
 	return nil
 }
@@ -44,3 +43,19 @@ func customMessage(trans ut.Translator, newValidator *validator.Validate) {
 		return t
 	})
 }
+
+// snakeCase turns the name of a struct field into the one of its json tag,
+// QuestionNumber into question_number.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
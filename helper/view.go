@@ -0,0 +1,335 @@
+package helper
+
+import (
+	"fmt"
+	"io"
+	"quiz_master/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// questionHeader names the CSV columns of questionRow.
+var questionHeader = []string{"number", "question", "answer", "answer_type", "lang", "grading", "fuzzy", "time_limit", "choices", "hints", "explanation", "tags", "aliases", "deleted_at"}
+
+// questionRow flattens q into one CSV row, lists joined with semicolons.
+func questionRow(q *domain.Question) []string {
+	grading, timeLimit, deletedAt := "", "", ""
+	if q.Grading.Rule != domain.GradingExact {
+		grading = FormatGrading(q.Grading)
+	}
+	if q.TimeLimit > 0 {
+		timeLimit = q.TimeLimit.String()
+	}
+	if q.DeletedAt != nil {
+		deletedAt = q.DeletedAt.UTC().Format(time.RFC3339)
+	}
+	choices := make([]string, len(q.Choices))
+	for i, c := range q.Choices {
+		choices[i] = c.Label + "=" + c.Text
+	}
+	return []string{q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, grading, strconv.Itoa(q.Fuzzy), timeLimit,
+		strings.Join(choices, ";"), strings.Join(q.Hints, ";"), q.Explanation, strings.Join(q.Tags, ";"), strings.Join(q.Aliases, ";"), deletedAt}
+}
+
+func questionRows(questions []*domain.Question) [][]string {
+	rows := make([][]string, len(questions))
+	for i, q := range questions {
+		rows[i] = questionRow(q)
+	}
+	return rows
+}
+
+func QuestionView(question domain.Question) View {
+	return View{
+		Data:   question,
+		Header: questionHeader,
+		Rows:   [][]string{questionRow(&question)},
+		Table: func(w io.Writer) {
+			QuestionResponse(w, question)
+		},
+	}
+}
+
+func CreatedQuestionView(question domain.Question) View {
+	view := QuestionView(question)
+	view.Table = func(w io.Writer) {
+		CreatedQuestionResponse(w, question)
+	}
+	return view
+}
+
+func ReplacedQuestionView(question domain.Question) View {
+	view := QuestionView(question)
+	view.Table = func(w io.Writer) {
+		ReplacedQuestionResponse(w, question)
+	}
+	return view
+}
+
+// QuestionPageOutput is a page of list_question, Limit being the page size.
+type QuestionPageOutput struct {
+	Questions []*domain.Question `json:"questions"`
+	Offset    int                `json:"offset"`
+	Limit     int                `json:"limit"`
+	Total     int                `json:"total"`
+}
+
+func ListQuestionView(page domain.QuestionPage, perPage int) View {
+	return View{
+		Data:   QuestionPageOutput{Questions: page.Questions, Offset: page.Offset, Limit: perPage, Total: page.Total},
+		Header: questionHeader,
+		Rows:   questionRows(page.Questions),
+		Table: func(w io.Writer) {
+			ListQuestionResponse(w, page, perPage)
+		},
+	}
+}
+
+func ListTrashView(questions []*domain.Question) View {
+	return View{
+		Data:   questions,
+		Header: questionHeader,
+		Rows:   questionRows(questions),
+		Table: func(w io.Writer) {
+			ListTrashResponse(w, questions)
+		},
+	}
+}
+
+// PurgeView shows how many questions purge_trash deleted.
+func PurgeView(purged int64) View {
+	return View{
+		Data:   map[string]int64{"purged": purged},
+		Header: []string{"purged"},
+		Rows:   [][]string{{strconv.FormatInt(purged, 10)}},
+		Table: func(w io.Writer) {
+			fmt.Fprintf(w, "%d question(s) purged from the trash\n", purged)
+		},
+	}
+}
+
+// AnswerOutput is the grade of answer_question. HintsUsed is how many hints
+// were shown before the answer and ScoreAfterHints its score once cut for
+// them.
+type AnswerOutput struct {
+	domain.AnswerResult
+	HintsUsed       int     `json:"hints_used,omitempty"`
+	ScoreAfterHints float64 `json:"score_after_hints,omitempty"`
+}
+
+// AnswerView shows the grade of an answer, verbose telling which accepted
+// answer matched or the score of a wrong one.
+func AnswerView(answer AnswerOutput, verbose bool) View {
+	return View{
+		Data:   answer,
+		Header: []string{"outcome", "score", "accepted", "alias", "distance", "feedback", "explanation", "hints_used", "score_after_hints"},
+		Rows: [][]string{{answer.Outcome, FormatScore(answer.Score), answer.Accepted, strconv.FormatBool(answer.Alias),
+			strconv.Itoa(answer.Distance), answer.Feedback, answer.Explanation, strconv.Itoa(answer.HintsUsed), FormatScore(answer.ScoreAfterHints)}},
+		Table: func(w io.Writer) {
+			switch {
+			case !verbose:
+				fmt.Fprintln(w, answer.Feedback)
+			case answer.Alias:
+				fmt.Fprintf(w, "%s (matched alias %q)\n", answer.Feedback, answer.Accepted)
+			case answer.Outcome == domain.OutcomeCorrect:
+				fmt.Fprintf(w, "%s (matched answer %q)\n", answer.Feedback, answer.Accepted)
+			default:
+				fmt.Fprintf(w, "%s (score %.2f)\n", answer.Feedback, answer.Score)
+			}
+			if answer.Explanation != "" {
+				fmt.Fprintf(w, "Explanation : %s\n", answer.Explanation)
+			}
+			if answer.HintsUsed > 0 {
+				fmt.Fprintf(w, "Hints used : %d, score %s\n", answer.HintsUsed, FormatScore(answer.ScoreAfterHints))
+			}
+		},
+	}
+}
+
+// UpdateOutput is what update_question changed in question Number.
+type UpdateOutput struct {
+	Number  string                  `json:"number"`
+	Changes []domain.QuestionChange `json:"changes"`
+}
+
+func UpdateView(number string, changes []domain.QuestionChange) View {
+	rows := make([][]string, len(changes))
+	for i, c := range changes {
+		rows[i] = []string{number, c.Field, c.Old, c.New}
+	}
+	return View{
+		Data:   UpdateOutput{Number: number, Changes: changes},
+		Header: []string{"number", "field", "old", "new"},
+		Rows:   rows,
+		Table: func(w io.Writer) {
+			if len(changes) == 0 {
+				fmt.Fprintf(w, "Question no %s is unchanged\n", number)
+				return
+			}
+			fmt.Fprintf(w, "Question no %s updated :\n", number)
+			for _, c := range changes {
+				fmt.Fprintf(w, "%s : %s -> %s\n", c.Field, c.Old, c.New)
+			}
+		},
+	}
+}
+
+// TagOutput is the tags tag_question added to question Number.
+type TagOutput struct {
+	Number string   `json:"number"`
+	Added  []string `json:"added"`
+}
+
+func TagView(number string, added []string) View {
+	rows := make([][]string, len(added))
+	for i, t := range added {
+		rows[i] = []string{number, t}
+	}
+	return View{
+		Data:   TagOutput{Number: number, Added: added},
+		Header: []string{"number", "tag"},
+		Rows:   rows,
+		Table: func(w io.Writer) {
+			if len(added) == 0 {
+				fmt.Fprintf(w, "Question no %s already has these tags\n", number)
+				return
+			}
+			fmt.Fprintf(w, "Question no %s tagged %s\n", number, strings.Join(added, ", "))
+		},
+	}
+}
+
+func ListTagsView(counts []domain.TagCount) View {
+	rows := make([][]string, len(counts))
+	for i, c := range counts {
+		rows[i] = []string{c.Tag, strconv.Itoa(c.Questions)}
+	}
+	return View{
+		Data:   counts,
+		Header: []string{"tag", "questions"},
+		Rows:   rows,
+		Table: func(w io.Writer) {
+			ListTagsResponse(w, counts)
+		},
+	}
+}
+
+func SearchView(terms string, results []domain.SearchResult) View {
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		deletedAt := ""
+		if r.Question.DeletedAt != nil {
+			deletedAt = r.Question.DeletedAt.UTC().Format(time.RFC3339)
+		}
+		rows[i] = []string{r.Question.Number, FormatScore(r.Score), r.Snippet, deletedAt}
+	}
+	return View{
+		Data:   results,
+		Header: []string{"number", "score", "snippet", "deleted_at"},
+		Rows:   rows,
+		Table: func(w io.Writer) {
+			if len(results) == 0 {
+				fmt.Fprintf(w, "No question matches %s\n", terms)
+				return
+			}
+			SearchResponse(w, results)
+		},
+	}
+}
+
+func HintView(use domain.HintUse) View {
+	return View{
+		Data:   use,
+		Header: []string{"player", "question_number", "hint", "total", "content"},
+		Rows:   [][]string{{use.Player, use.QuestionNumber, strconv.Itoa(use.Hint), strconv.Itoa(use.Total), use.Content}},
+		Table: func(w io.Writer) {
+			fmt.Fprintf(w, "Hint %d/%d : %s\n", use.Hint, use.Total, use.Content)
+		},
+	}
+}
+
+// attemptHeader names the CSV columns of attemptRow.
+var attemptHeader = []string{"answered_at", "player", "question_number", "answer", "correct", "timed_out", "score", "hints_used", "duration"}
+
+func attemptRow(a *domain.Attempt) []string {
+	return []string{a.AnsweredAt.UTC().Format(time.RFC3339), a.Player, a.QuestionNumber, a.Answer, strconv.FormatBool(a.Correct),
+		strconv.FormatBool(a.TimedOut), FormatScore(a.Score), strconv.Itoa(a.HintsUsed), a.Duration.String()}
+}
+
+func HistoryView(attempts []*domain.Attempt) View {
+	rows := make([][]string, len(attempts))
+	for i, a := range attempts {
+		rows[i] = attemptRow(a)
+	}
+	return View{
+		Data:   attempts,
+		Header: attemptHeader,
+		Rows:   rows,
+		Table: func(w io.Writer) {
+			HistoryResponse(w, attempts)
+		},
+	}
+}
+
+// ScoreView shows the score of a player, one CSV row per question.
+func ScoreView(score domain.PlayerScore) View {
+	rows := make([][]string, len(score.Questions))
+	for i, q := range score.Questions {
+		rows[i] = []string{score.Player, q.QuestionNumber, strconv.Itoa(q.Attempts), strconv.Itoa(q.Correct), FormatScore(q.Score)}
+	}
+	return View{
+		Data:   score,
+		Header: []string{"player", "question_number", "attempts", "correct", "score"},
+		Rows:   rows,
+		Table: func(w io.Writer) {
+			ScoreResponse(w, score)
+		},
+	}
+}
+
+func LeaderboardView(entries []domain.LeaderboardEntry) View {
+	rows := make([][]string, len(entries))
+	for i, e := range entries {
+		rows[i] = []string{strconv.Itoa(e.Rank), e.Player, FormatScore(e.Points), Percent(e.Correct, e.Attempts),
+			strconv.Itoa(e.Streak), strconv.Itoa(e.Attempts), strconv.Itoa(e.Correct)}
+	}
+	return View{
+		Data:   entries,
+		Header: []string{"rank", "player", "points", "accuracy", "streak", "attempts", "correct"},
+		Rows:   rows,
+		Table: func(w io.Writer) {
+			LeaderboardResponse(w, entries)
+		},
+	}
+}
+
+// PlayOutput is how a quiz went, its attempts in the order answered.
+type PlayOutput struct {
+	Player   string            `json:"player"`
+	Attempts []*domain.Attempt `json:"attempts"`
+	Answered int               `json:"answered"`
+	Correct  int               `json:"correct"`
+	TimedOut int               `json:"timed_out"`
+	Score    float64           `json:"score"`
+}
+
+func PlayView(play PlayOutput) View {
+	rows := make([][]string, len(play.Attempts))
+	for i, a := range play.Attempts {
+		rows[i] = attemptRow(a)
+	}
+	return View{
+		Data:   play,
+		Header: attemptHeader,
+		Rows:   rows,
+		Table: func(w io.Writer) {
+			fmt.Fprintf(w, "Score : %s/%d, %d of %d answered correctly",
+				FormatScore(play.Score), play.Answered, play.Correct, play.Answered)
+			if play.TimedOut > 0 {
+				fmt.Fprintf(w, ", %d timed out", play.TimedOut)
+			}
+			fmt.Fprintln(w)
+		},
+	}
+}
@@ -0,0 +1,341 @@
+package number
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	English    = "en"
+	Indonesian = "id"
+	Spanish    = "es"
+	French     = "fr"
+	German     = "de"
+)
+
+// lexKind tells how a number word of another language than English combines
+// with the words before it.
+type lexKind int
+
+const (
+	// lexValue adds its value, e.g. "dua", "veintiuno" or "zwanzig".
+	lexValue lexKind = iota
+	// lexMultiplier multiplies what precedes it, e.g. "puluh" or "cent".
+	lexMultiplier
+	// lexTeen adds ten to the unit before it, e.g. "belas".
+	lexTeen
+	// lexScale closes a group of three digits, e.g. "ribu" or "mille".
+	lexScale
+	// lexZero is only valid on its own or before the decimal separator.
+	lexZero
+)
+
+type lexeme struct {
+	kind  lexKind
+	value int64
+}
+
+// language describes how a language other than English writes numbers.
+type language struct {
+	words    map[string]lexeme
+	negative []string
+	point    []string
+	// joiners are skipped, e.g. the "y" of "treinta y uno".
+	joiners []string
+	// compound languages write numbers as one word, e.g. "einundzwanzig".
+	compound bool
+	// replacer rewrites spellings made of several words into one.
+	replacer *strings.Replacer
+}
+
+var languages = map[string]*language{
+	Indonesian: {
+		words: map[string]lexeme{
+			"nol": {lexZero, 0}, "kosong": {lexZero, 0},
+			"satu": {lexValue, 1}, "dua": {lexValue, 2}, "tiga": {lexValue, 3}, "empat": {lexValue, 4}, "lima": {lexValue, 5},
+			"enam": {lexValue, 6}, "tujuh": {lexValue, 7}, "delapan": {lexValue, 8}, "sembilan": {lexValue, 9},
+			"sepuluh": {lexValue, 10}, "sebelas": {lexValue, 11}, "seratus": {lexValue, 100},
+			"belas": {lexTeen, 10}, "puluh": {lexMultiplier, 10}, "ratus": {lexMultiplier, 100},
+			"seribu": {lexScale, 1e3}, "ribu": {lexScale, 1e3}, "sejuta": {lexScale, 1e6}, "juta": {lexScale, 1e6},
+			"miliar": {lexScale, 1e9}, "milyar": {lexScale, 1e9}, "triliun": {lexScale, 1e12},
+		},
+		negative: []string{"minus", "negatif"},
+		point:    []string{"koma"},
+	},
+	Spanish: {
+		words: map[string]lexeme{
+			"cero": {lexZero, 0},
+			"un":   {lexValue, 1}, "uno": {lexValue, 1}, "una": {lexValue, 1}, "dos": {lexValue, 2}, "tres": {lexValue, 3},
+			"cuatro": {lexValue, 4}, "cinco": {lexValue, 5}, "seis": {lexValue, 6}, "siete": {lexValue, 7},
+			"ocho": {lexValue, 8}, "nueve": {lexValue, 9}, "diez": {lexValue, 10}, "once": {lexValue, 11},
+			"doce": {lexValue, 12}, "trece": {lexValue, 13}, "catorce": {lexValue, 14}, "quince": {lexValue, 15},
+			"dieciseis": {lexValue, 16}, "diecisiete": {lexValue, 17}, "dieciocho": {lexValue, 18}, "diecinueve": {lexValue, 19},
+			"veinte": {lexValue, 20}, "veintiun": {lexValue, 21}, "veintiuno": {lexValue, 21}, "veintiuna": {lexValue, 21},
+			"veintidos": {lexValue, 22}, "veintitres": {lexValue, 23}, "veinticuatro": {lexValue, 24}, "veinticinco": {lexValue, 25},
+			"veintiseis": {lexValue, 26}, "veintisiete": {lexValue, 27}, "veintiocho": {lexValue, 28}, "veintinueve": {lexValue, 29},
+			"treinta": {lexValue, 30}, "cuarenta": {lexValue, 40}, "cincuenta": {lexValue, 50}, "sesenta": {lexValue, 60},
+			"setenta": {lexValue, 70}, "ochenta": {lexValue, 80}, "noventa": {lexValue, 90},
+			"cien": {lexValue, 100}, "ciento": {lexValue, 100},
+			"doscientos": {lexValue, 200}, "doscientas": {lexValue, 200}, "trescientos": {lexValue, 300}, "trescientas": {lexValue, 300},
+			"cuatrocientos": {lexValue, 400}, "cuatrocientas": {lexValue, 400}, "quinientos": {lexValue, 500}, "quinientas": {lexValue, 500},
+			"seiscientos": {lexValue, 600}, "seiscientas": {lexValue, 600}, "setecientos": {lexValue, 700}, "setecientas": {lexValue, 700},
+			"ochocientos": {lexValue, 800}, "ochocientas": {lexValue, 800}, "novecientos": {lexValue, 900}, "novecientas": {lexValue, 900},
+			"mil": {lexScale, 1e3}, "millon": {lexScale, 1e6}, "millones": {lexScale, 1e6},
+			"billon": {lexScale, 1e12}, "billones": {lexScale, 1e12},
+		},
+		negative: []string{"menos"},
+		point:    []string{"coma", "punto"},
+		joiners:  []string{"y"},
+	},
+	French: {
+		words: map[string]lexeme{
+			"zero": {lexZero, 0},
+			"un":   {lexValue, 1}, "une": {lexValue, 1}, "deux": {lexValue, 2}, "trois": {lexValue, 3}, "quatre": {lexValue, 4},
+			"cinq": {lexValue, 5}, "six": {lexValue, 6}, "sept": {lexValue, 7}, "huit": {lexValue, 8}, "neuf": {lexValue, 9},
+			"dix": {lexValue, 10}, "onze": {lexValue, 11}, "douze": {lexValue, 12}, "treize": {lexValue, 13},
+			"quatorze": {lexValue, 14}, "quinze": {lexValue, 15}, "seize": {lexValue, 16},
+			"vingt": {lexValue, 20}, "vingts": {lexValue, 20}, "trente": {lexValue, 30}, "quarante": {lexValue, 40},
+			"cinquante": {lexValue, 50}, "soixante": {lexValue, 60}, "septante": {lexValue, 70},
+			"quatrevingt": {lexValue, 80}, "quatrevingts": {lexValue, 80}, "huitante": {lexValue, 80}, "octante": {lexValue, 80},
+			"nonante": {lexValue, 90},
+			"cent":    {lexMultiplier, 100}, "cents": {lexMultiplier, 100},
+			"mille": {lexScale, 1e3}, "million": {lexScale, 1e6}, "millions": {lexScale, 1e6},
+			"milliard": {lexScale, 1e9}, "milliards": {lexScale, 1e9},
+		},
+		negative: []string{"moins"},
+		point:    []string{"virgule"},
+		joiners:  []string{"et"},
+		replacer: strings.NewReplacer("quatre vingts", "quatrevingts", "quatre vingt", "quatrevingt"),
+	},
+	German: {
+		words: map[string]lexeme{
+			"null": {lexZero, 0},
+			"ein":  {lexValue, 1}, "eins": {lexValue, 1}, "eine": {lexValue, 1}, "zwei": {lexValue, 2}, "drei": {lexValue, 3},
+			"vier": {lexValue, 4}, "funf": {lexValue, 5}, "sechs": {lexValue, 6}, "sieben": {lexValue, 7},
+			"acht": {lexValue, 8}, "neun": {lexValue, 9}, "zehn": {lexValue, 10}, "elf": {lexValue, 11}, "zwolf": {lexValue, 12},
+			"dreizehn": {lexValue, 13}, "vierzehn": {lexValue, 14}, "funfzehn": {lexValue, 15}, "sechzehn": {lexValue, 16},
+			"siebzehn": {lexValue, 17}, "achtzehn": {lexValue, 18}, "neunzehn": {lexValue, 19},
+			"zwanzig": {lexValue, 20}, "dreissig": {lexValue, 30}, "vierzig": {lexValue, 40}, "funfzig": {lexValue, 50},
+			"sechzig": {lexValue, 60}, "siebzig": {lexValue, 70}, "achtzig": {lexValue, 80}, "neunzig": {lexValue, 90},
+			"hundert": {lexMultiplier, 100}, "tausend": {lexScale, 1e3},
+			"million": {lexScale, 1e6}, "millionen": {lexScale, 1e6}, "milliarde": {lexScale, 1e9}, "milliarden": {lexScale, 1e9},
+		},
+		negative: []string{"minus"},
+		point:    []string{"komma"},
+		joiners:  []string{"und"},
+		compound: true,
+	},
+}
+
+// Languages lists the codes of the languages whose number words are read.
+func Languages() []string {
+	codes := []string{English}
+	for code := range languages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes[1:])
+	return codes
+}
+
+// Supported reports whether the number words of lang are read.
+func Supported(lang string) bool {
+	_, ok := languages[lang]
+	return ok || lang == English
+}
+
+func (l *language) parseWords(input string) (*big.Rat, bool) {
+	input, negative := trimSign(input)
+	removeMarks := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	if plain, _, err := transform.String(removeMarks, input); err == nil {
+		input = plain
+	}
+	input = strings.NewReplacer("ß", "ss", "-", " ", ",", " ").Replace(input)
+	if l.replacer != nil {
+		input = l.replacer.Replace(strings.Join(strings.Fields(input), " "))
+	}
+
+	tokens := []string{}
+	for _, t := range strings.Fields(input) {
+		if !l.compound {
+			tokens = append(tokens, t)
+			continue
+		}
+		parts, ok := l.split(t)
+		if !ok {
+			return nil, false
+		}
+		tokens = append(tokens, parts...)
+	}
+
+	if len(tokens) > 0 && contains(l.negative, tokens[0]) {
+		if negative {
+			return nil, false
+		}
+		negative = true
+		tokens = tokens[1:]
+	}
+
+	r, ok := l.parseTokens(tokens)
+	if !ok {
+		return nil, false
+	}
+	if negative {
+		r.Neg(r)
+	}
+	return r, true
+}
+
+func (l *language) parseTokens(tokens []string) (*big.Rat, bool) {
+	for i, t := range tokens {
+		if !contains(l.point, t) {
+			continue
+		}
+		whole := new(big.Int)
+		if i > 0 {
+			n, ok := l.parseCardinal(tokens[:i])
+			if !ok {
+				return nil, false
+			}
+			whole = n
+		}
+		decimals := ""
+		for _, d := range tokens[i+1:] {
+			w, ok := l.words[d]
+			if !ok || (w.kind != lexValue && w.kind != lexZero) || w.value > 9 {
+				return nil, false
+			}
+			decimals += string(rune('0' + w.value))
+		}
+		if decimals == "" {
+			return nil, false
+		}
+		return new(big.Rat).SetString(whole.String() + "." + decimals)
+	}
+
+	n, ok := l.parseCardinal(tokens)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).SetInt(n), true
+}
+
+// parseCardinal reads whole numbers. It is more lenient than the English
+// reader, only two units in a row are refused.
+func (l *language) parseCardinal(tokens []string) (*big.Int, bool) {
+	if len(tokens) == 1 {
+		if w, ok := l.words[tokens[0]]; ok && w.kind == lexZero {
+			return new(big.Int), true
+		}
+	}
+
+	total := new(big.Int)
+	var group, small int64
+	var prevScale int64
+	prevUnit, seen := false, false
+	for i, t := range tokens {
+		if contains(l.joiners, t) {
+			if i == 0 || i == len(tokens)-1 {
+				return nil, false
+			}
+			continue
+		}
+		w, ok := l.words[t]
+		if !ok || w.kind == lexZero {
+			return nil, false
+		}
+		seen = true
+
+		unit := w.kind == lexValue && w.value < 10
+		if unit && prevUnit {
+			return nil, false
+		}
+		prevUnit = unit
+
+		switch w.kind {
+		case lexValue:
+			small += w.value
+		case lexTeen:
+			if small == 0 || small > 9 {
+				return nil, false
+			}
+			small += w.value
+		case lexMultiplier:
+			if small == 0 {
+				small = 1
+			}
+			group += small * w.value
+			small = 0
+		case lexScale:
+			segment := group + small
+			scale := big.NewInt(w.value)
+			if prevScale != 0 && w.value >= prevScale {
+				// "mil millones", a thousand millions
+				total.Add(total, big.NewInt(segment))
+				total.Mul(total, scale)
+			} else {
+				if segment == 0 {
+					segment = 1
+				}
+				total.Add(total, new(big.Int).Mul(big.NewInt(segment), scale))
+			}
+			group, small = 0, 0
+			prevScale = w.value
+		}
+	}
+
+	if !seen {
+		return nil, false
+	}
+	return total.Add(total, big.NewInt(group+small)), true
+}
+
+// split cuts a compound such as "zweihundertdreiundvierzig" into the words
+// it is made of, preferring the longest words.
+func (l *language) split(token string) ([]string, bool) {
+	vocabulary := append(append(append([]string{}, l.joiners...), l.negative...), l.point...)
+	for w := range l.words {
+		vocabulary = append(vocabulary, w)
+	}
+	// longest first, so "achtzehn" wins over "acht" "zehn"
+	sort.Slice(vocabulary, func(i, j int) bool { return len(vocabulary[i]) > len(vocabulary[j]) })
+
+	// parts[i] holds a split of token[i:], failed[i] that there is none
+	parts := map[int][]string{len(token): {}}
+	failed := map[int]bool{}
+	var from func(i int) ([]string, bool)
+	from = func(i int) ([]string, bool) {
+		if p, ok := parts[i]; ok {
+			return p, true
+		}
+		if failed[i] {
+			return nil, false
+		}
+		for _, w := range vocabulary {
+			if !strings.HasPrefix(token[i:], w) {
+				continue
+			}
+			if rest, ok := from(i + len(w)); ok {
+				parts[i] = append([]string{w}, rest...)
+				return parts[i], true
+			}
+		}
+		failed[i] = true
+		return nil, false
+	}
+	return from(0)
+}
+
+func contains(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package number
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_Languages(t *testing.T) {
+	tests := []struct {
+		lang  string
+		input string
+		want  string
+	}{
+		{Indonesian, "nol", "0"},
+		{Indonesian, "dua puluh satu", "21"},
+		{Indonesian, "sebelas", "11"},
+		{Indonesian, "dua belas", "12"},
+		{Indonesian, "seratus dua", "102"},
+		{Indonesian, "tiga ratus empat puluh lima", "345"},
+		{Indonesian, "seribu sembilan ratus sembilan puluh delapan", "1998"},
+		{Indonesian, "dua juta tiga ribu", "2003000"},
+		{Indonesian, "minus lima", "-5"},
+		{Indonesian, "tiga koma lima", "7/2"},
+		{Indonesian, "21", "21"},
+		{Spanish, "veintiuno", "21"},
+		{Spanish, "treinta y uno", "31"},
+		{Spanish, "ciento veintitrés", "123"},
+		{Spanish, "quinientos", "500"},
+		{Spanish, "mil novecientos noventa y ocho", "1998"},
+		{Spanish, "dos millones", "2000000"},
+		{Spanish, "mil millones", "1000000000"},
+		{Spanish, "menos cinco", "-5"},
+		{Spanish, "tres coma cinco", "7/2"},
+		{French, "vingt et un", "21"},
+		{French, "vingt-deux", "22"},
+		{French, "soixante-dix", "70"},
+		{French, "soixante et onze", "71"},
+		{French, "quatre-vingts", "80"},
+		{French, "quatre-vingt-dix-neuf", "99"},
+		{French, "septante", "70"},
+		{French, "deux cents", "200"},
+		{French, "mille neuf cent quatre-vingt-dix-huit", "1998"},
+		{French, "moins cinq", "-5"},
+		{French, "zéro virgule cinq", "1/2"},
+		{German, "null", "0"},
+		{German, "einundzwanzig", "21"},
+		{German, "ein und zwanzig", "21"},
+		{German, "achtzehn", "18"},
+		{German, "dreißig", "30"},
+		{German, "fünfhundert", "500"},
+		{German, "zweihundertdreiundvierzig", "243"},
+		{German, "eintausendneunhundertachtundneunzig", "1998"},
+		{German, "zwei Millionen", "2000000"},
+		{German, "minus fünf", "-5"},
+		{German, "drei Komma fünf", "7/2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang+" "+tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, tt.lang)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got.RatString())
+			}
+		})
+	}
+}
+
+func TestParse_LanguagesInvalid(t *testing.T) {
+	tests := []struct {
+		lang  string
+		input string
+	}{
+		{Indonesian, "dua dua"},
+		{Indonesian, "belas"},
+		{Indonesian, "twenty one"},
+		{Spanish, "y"},
+		{Spanish, "uno y"},
+		{French, "et"},
+		{German, "zweizwei"},
+		{German, "einsx"},
+		{German, "komma"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang+" "+tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input, tt.lang)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParse_SeveralLanguages(t *testing.T) {
+	got, err := Parse("dua puluh satu", English, Indonesian)
+	assert.NoError(t, err)
+	assert.Equal(t, "21", got.RatString())
+
+	got, err = Parse("twenty-one", English, Indonesian)
+	assert.NoError(t, err)
+	assert.Equal(t, "21", got.RatString())
+
+	_, err = Parse("twenty-one", Indonesian)
+	assert.Error(t, err)
+
+	_, err = Parse("vingt", "xx")
+	assert.Error(t, err)
+}
+
+func TestLanguages(t *testing.T) {
+	assert.Equal(t, []string{"en", "de", "es", "fr", "id"}, Languages())
+	assert.True(t, Supported("id"))
+	assert.False(t, Supported("xx"))
+}
+
+func FuzzParseLanguages(f *testing.F) {
+	for _, seed := range []string{"dua puluh satu", "treinta y uno", "quatre-vingt-dix-neuf", "zweihundertdreiundvierzig", "einseinseinseinseinseinseinseinseinsx"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, lang := range Languages() {
+			r, err := Parse(input, lang)
+			if err != nil {
+				continue
+			}
+			again, err := Parse(r.RatString())
+			if err != nil || again.Cmp(r) != 0 {
+				t.Fatalf("Parse(%q, %s) = %s, which does not read back", input, lang, r.RatString())
+			}
+		}
+	})
+}
@@ -0,0 +1,123 @@
+// Package number reads numeric answers, written with digits or in English
+// words, into exact values so that answers are compared by value instead of
+// by spelling.
+package number
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+var (
+	plainNumber = regexp.MustCompile(`^(\d+(\.\d*)?|\.\d+)$`)
+	fraction    = regexp.MustCompile(`^(\d+)\s*/\s*(\d+)$`)
+	mixedNumber = regexp.MustCompile(`^(\d+)\s+(\d+)\s*/\s*(\d+)$`)
+	// groupedNumbers match thousands separated by a comma, a space, an
+	// underscore or an apostrophe, used consistently.
+	groupedNumbers = []*regexp.Regexp{
+		regexp.MustCompile(`^\d{1,3}(,\d{3})+(\.\d+)?$`),
+		regexp.MustCompile(`^\d{1,3}( \d{3})+(\.\d+)?$`),
+		regexp.MustCompile(`^\d{1,3}(_\d{3})+(\.\d+)?$`),
+		regexp.MustCompile(`^\d{1,3}('\d{3})+(\.\d+)?$`),
+	}
+)
+
+// Parse reads s, e.g. "-1,000", "3.5", "3/4", "1 3/4", "1.5 million",
+// "twenty-one", "minus five", "one hundred and two" or "two and three
+// quarters", into its exact value. Digits are always read, number words in
+// the languages given in langs, English when there are none.
+func Parse(s string, langs ...string) (*big.Rat, error) {
+	// Fields also splits on non-breaking and thin spaces.
+	input := strings.ToLower(strings.ReplaceAll(s, "\u2212", "-"))
+	input = strings.Join(strings.Fields(input), " ")
+
+	if r, ok := parseDigits(input); ok {
+		return r, nil
+	}
+
+	if len(langs) == 0 {
+		langs = []string{English}
+	}
+	for _, lang := range langs {
+		if lang == English {
+			if r, ok := parseWords(input); ok {
+				return r, nil
+			}
+			continue
+		}
+		l, ok := languages[lang]
+		if !ok {
+			return nil, fmt.Errorf("number words in %q are not supported", lang)
+		}
+		if r, ok := l.parseWords(input); ok {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("%q is not a number", s)
+}
+
+// Equal reports whether a and b are both numbers of the same value, reading
+// number words in langs.
+func Equal(a, b string, langs ...string) bool {
+	x, err := Parse(a, langs...)
+	if err != nil {
+		return false
+	}
+	y, err := Parse(b, langs...)
+	if err != nil {
+		return false
+	}
+	return x.Cmp(y) == 0
+}
+
+func parseDigits(input string) (*big.Rat, bool) {
+	input, negative := trimSign(input)
+
+	r := new(big.Rat)
+	ok := false
+	if m := mixedNumber.FindStringSubmatch(input); m != nil {
+		if _, ok = r.SetString(m[2] + "/" + m[3]); ok {
+			whole, _ := new(big.Rat).SetString(m[1])
+			r.Add(r, whole)
+		}
+	} else if m := fraction.FindStringSubmatch(input); m != nil {
+		_, ok = r.SetString(m[1] + "/" + m[2])
+	} else if plainNumber.MatchString(input) {
+		_, ok = r.SetString(input)
+	} else {
+		for _, grouped := range groupedNumbers {
+			if grouped.MatchString(input) {
+				_, ok = r.SetString(strings.Map(dropSeparator, input))
+				break
+			}
+		}
+	}
+
+	if !ok {
+		return nil, false
+	}
+	if negative {
+		r.Neg(r)
+	}
+	return r, true
+}
+
+func trimSign(input string) (string, bool) {
+	switch {
+	case strings.HasPrefix(input, "-"):
+		return strings.TrimSpace(input[1:]), true
+	case strings.HasPrefix(input, "+"):
+		return strings.TrimSpace(input[1:]), false
+	}
+	return input, false
+}
+
+func dropSeparator(r rune) rune {
+	switch r {
+	case ',', ' ', '_', '\'':
+		return -1
+	}
+	return r
+}
@@ -0,0 +1,246 @@
+package number
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"0", "0"},
+		{"42", "42"},
+		{" 42 ", "42"},
+		{"+42", "42"},
+		{"-5", "-5"},
+		{"- 5", "-5"},
+		{"−5", "-5"},
+		{"007", "7"},
+		{"3.5", "7/2"},
+		{"-0.25", "-1/4"},
+		{".5", "1/2"},
+		{"2.50", "5/2"},
+		{"1,000", "1000"},
+		{"1,234,567.5", "2469135/2"},
+		{"1 000 000", "1000000"},
+		{"1 000", "1000"},
+		{"1_000", "1000"},
+		{"1'000", "1000"},
+		{"3/4", "3/4"},
+		{"6/8", "3/4"},
+		{"-3 / 4", "-3/4"},
+		{"1 3/4", "7/4"},
+		{"12345678901234567890123", "12345678901234567890123"},
+		{"1.5 million", "1500000"},
+		{"2 thousand", "2000"},
+		{"-1 million", "-1000000"},
+		{"zero", "0"},
+		{"Two", "2"},
+		{"eleven", "11"},
+		{"twenty", "20"},
+		{"twenty-one", "21"},
+		{"twenty one", "21"},
+		{"TWENTY-ONE", "21"},
+		{"minus five", "-5"},
+		{"negative twelve", "-12"},
+		{"-five", "-5"},
+		{"a hundred", "100"},
+		{"one hundred and two", "102"},
+		{"one hundred two", "102"},
+		{"fifteen hundred", "1500"},
+		{"twenty-one hundred", "2100"},
+		{"one thousand, two hundred and thirty-four", "1234"},
+		{"a thousand and five", "1005"},
+		{"nine hundred ninety-nine thousand nine hundred ninety-nine", "999999"},
+		{"two million three thousand", "2003000"},
+		{"ten quintillion", "10000000000000000000"},
+		{"three point one four", "157/50"},
+		{"point five", "1/2"},
+		{"zero point oh five", "1/20"},
+		{"a half", "1/2"},
+		{"one half", "1/2"},
+		{"three quarters", "3/4"},
+		{"three fourths", "3/4"},
+		{"two thirds", "2/3"},
+		{"one and a half", "3/2"},
+		{"two and three quarters", "11/4"},
+		{"minus one and a half", "-3/2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got.RatString())
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		" ",
+		"-",
+		"abc",
+		"1,5",
+		"1,00",
+		"10,00,000",
+		"1,000 000",
+		"1..5",
+		"1.5.2",
+		"3/0",
+		"1/2/3",
+		"--5",
+		"- minus five",
+		"five five",
+		"twenty twenty",
+		"eleven one",
+		"one twenty",
+		"hundred",
+		"one hundred hundred",
+		"a",
+		"a five",
+		"thousand",
+		"one thousand one thousand",
+		"one thousand one million",
+		"and five",
+		"one hundred and",
+		"five and",
+		"point",
+		"three point",
+		"three point twelve",
+		"half",
+		"and a half",
+		"one half half",
+		"zero zero",
+		"one zero",
+		"1.5 million thousand",
+		"1 -1 million",
+		"--1 million",
+	}
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			_, err := Parse(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	assert.True(t, Equal("1000", "one thousand"))
+	assert.True(t, Equal("0.75", "three quarters"))
+	assert.True(t, Equal("-5", "minus five"))
+	assert.True(t, Equal("21", "twenty one"))
+	assert.False(t, Equal("21", "twenty"))
+	assert.False(t, Equal("abc", "abc"))
+}
+
+// spell writes n in English words, optionally with the "and" and hyphens
+// people often use.
+func spell(n *big.Int, and bool, hyphen bool) string {
+	if n.Sign() == 0 {
+		return "zero"
+	}
+	if n.Sign() < 0 {
+		return "minus " + spell(new(big.Int).Neg(n), and, hyphen)
+	}
+
+	names := []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion", "sextillion", "septillion", "octillion", "nonillion", "decillion"}
+	small := strings.Fields("zero one two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen sixteen seventeen eighteen nineteen")
+	tensNames := strings.Fields("_ _ twenty thirty forty fifty sixty seventy eighty ninety")
+
+	groups := []int64{}
+	thousand := big.NewInt(1000)
+	for rest := new(big.Int).Set(n); rest.Sign() > 0; {
+		m := new(big.Int)
+		rest.DivMod(rest, thousand, m)
+		groups = append(groups, m.Int64())
+	}
+
+	words := []string{}
+	for i := len(groups) - 1; i >= 0; i-- {
+		g := groups[i]
+		if g == 0 {
+			continue
+		}
+		if g >= 100 {
+			words = append(words, small[g/100], "hundred")
+			g %= 100
+			if g > 0 && and {
+				words = append(words, "and")
+			}
+		}
+		switch {
+		case g >= 20 && g%10 != 0 && hyphen:
+			words = append(words, tensNames[g/10]+"-"+small[g%10])
+		case g >= 20 && g%10 != 0:
+			words = append(words, tensNames[g/10], small[g%10])
+		case g >= 20:
+			words = append(words, tensNames[g/10])
+		case g > 0:
+			words = append(words, small[g])
+		}
+		if i > 0 {
+			words = append(words, names[i])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func TestParse_Spelled(t *testing.T) {
+	for _, n := range []int64{1, 9, 10, 19, 20, 21, 99, 100, 101, 110, 999, 1000, 1001, 1100, 12345, 100000, 1000001, 987654321} {
+		want := big.NewInt(n)
+		for _, and := range []bool{false, true} {
+			for _, hyphen := range []bool{false, true} {
+				got, err := Parse(spell(want, and, hyphen))
+				if assert.NoError(t, err, spell(want, and, hyphen)) {
+					assert.Equal(t, want.String(), got.RatString())
+				}
+			}
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{"42", "-3.5", "1,000", "3/4", "1 3/4", "twenty-one", "minus one hundred and two", "two and three quarters", "three point one four", "1.5 million"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		r, err := Parse(input)
+		if err != nil {
+			return
+		}
+		// whatever was read has to read back the same from its canonical form
+		again, err := Parse(r.RatString())
+		if err != nil {
+			t.Fatalf("Parse(%q) = %s, which does not parse: %v", input, r.RatString(), err)
+		}
+		if again.Cmp(r) != 0 {
+			t.Fatalf("Parse(%q) = %s, reads back as %s", input, r.RatString(), again.RatString())
+		}
+	})
+}
+
+func FuzzParseSpelled(f *testing.F) {
+	for _, seed := range []int64{0, 7, -21, 1000, 1002, -999999, 9223372036854775807, -9223372036854775808} {
+		f.Add(seed, false, false)
+	}
+	f.Fuzz(func(t *testing.T, n int64, and bool, hyphen bool) {
+		want := big.NewInt(n)
+		for _, input := range []string{strconv.FormatInt(n, 10), spell(want, and, hyphen)} {
+			got, err := Parse(input)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", input, err)
+			}
+			if got.Cmp(new(big.Rat).SetInt(want)) != 0 {
+				t.Fatalf("Parse(%q) = %s, want %d", input, got.RatString(), n)
+			}
+		}
+	})
+}
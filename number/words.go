@@ -0,0 +1,264 @@
+package number
+
+import (
+	"math/big"
+	"strings"
+)
+
+var units = map[string]int64{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9,
+}
+
+var teens = map[string]int64{
+	"ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14,
+	"fifteen": 15, "sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+}
+
+var tens = map[string]int64{
+	"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+	"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+}
+
+// scales are the short scale powers of ten.
+var scales = map[string]*big.Int{
+	"thousand":    pow10(3),
+	"million":     pow10(6),
+	"billion":     pow10(9),
+	"trillion":    pow10(12),
+	"quadrillion": pow10(15),
+	"quintillion": pow10(18),
+	"sextillion":  pow10(21),
+	"septillion":  pow10(24),
+	"octillion":   pow10(27),
+	"nonillion":   pow10(30),
+	"decillion":   pow10(33),
+}
+
+var digits = map[string]string{
+	"zero": "0", "oh": "0", "one": "1", "two": "2", "three": "3", "four": "4",
+	"five": "5", "six": "6", "seven": "7", "eight": "8", "nine": "9",
+}
+
+var denominators = map[string]int64{
+	"half": 2, "halves": 2,
+	"third": 3, "thirds": 3,
+	"quarter": 4, "quarters": 4, "fourth": 4, "fourths": 4,
+	"fifth": 5, "fifths": 5,
+	"sixth": 6, "sixths": 6,
+	"seventh": 7, "sevenths": 7,
+	"eighth": 8, "eighths": 8,
+	"ninth": 9, "ninths": 9,
+	"tenth": 10, "tenths": 10,
+	"hundredth": 100, "hundredths": 100,
+	"thousandth": 1000, "thousandths": 1000,
+}
+
+// wordKind is the kind of the previous word while reading a cardinal, it
+// decides which words may follow.
+type wordKind int
+
+const (
+	kindStart wordKind = iota
+	kindUnit
+	kindTeen
+	kindTens
+	kindHundred
+	kindScale
+)
+
+func parseWords(input string) (*big.Rat, bool) {
+	input, negative := trimSign(input)
+	if fields := strings.Fields(input); len(fields) > 0 && (fields[0] == "minus" || fields[0] == "negative") {
+		if negative {
+			return nil, false
+		}
+		negative = true
+		input = strings.Join(fields[1:], " ")
+	}
+
+	r, ok := parseUnsignedWords(input)
+	if !ok {
+		return nil, false
+	}
+	if negative {
+		r.Neg(r)
+	}
+	return r, true
+}
+
+func parseUnsignedWords(input string) (*big.Rat, bool) {
+	// digits followed by a scale, e.g. "1.5 million"
+	if fields := strings.Fields(input); len(fields) == 2 {
+		if scale, ok := scales[fields[1]]; ok && !strings.ContainsAny(fields[0][:1], "+-") {
+			if r, ok := parseDigits(fields[0]); ok {
+				return r.Mul(r, new(big.Rat).SetInt(scale)), true
+			}
+		}
+	}
+
+	tokens := strings.Fields(strings.NewReplacer("-", " ", ",", " ").Replace(input))
+	if len(tokens) == 0 {
+		return nil, false
+	}
+
+	// "three point one four"
+	if i := indexOf(tokens, "point"); i >= 0 {
+		whole := new(big.Int)
+		if i > 0 {
+			n, ok := parseCardinal(tokens[:i])
+			if !ok {
+				return nil, false
+			}
+			whole = n
+		}
+		decimals := ""
+		for _, t := range tokens[i+1:] {
+			d, ok := digits[t]
+			if !ok {
+				return nil, false
+			}
+			decimals += d
+		}
+		if decimals == "" {
+			return nil, false
+		}
+		return new(big.Rat).SetString(whole.String() + "." + decimals)
+	}
+
+	// "three quarters", "a half", "two and three quarters"
+	last := len(tokens) - 1
+	if den, ok := denominators[tokens[last]]; ok {
+		rest := tokens[:last]
+		if j := lastIndexOf(rest, "and"); j > 0 {
+			whole, okWhole := parseCardinal(rest[:j])
+			num, okNum := parseNumerator(rest[j+1:])
+			if okWhole && okNum {
+				r := new(big.Rat).SetFrac(num, big.NewInt(den))
+				return r.Add(r, new(big.Rat).SetInt(whole)), true
+			}
+		}
+		num, ok := parseNumerator(rest)
+		if !ok {
+			return nil, false
+		}
+		return new(big.Rat).SetFrac(num, big.NewInt(den)), true
+	}
+
+	n, ok := parseCardinal(tokens)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Rat).SetInt(n), true
+}
+
+func parseNumerator(tokens []string) (*big.Int, bool) {
+	if len(tokens) == 1 && (tokens[0] == "a" || tokens[0] == "an") {
+		return big.NewInt(1), true
+	}
+	return parseCardinal(tokens)
+}
+
+// parseCardinal reads whole numbers such as "twenty one", "fifteen hundred"
+// or "a thousand and five". Words have to come in a valid order, so "five
+// five" is rejected instead of being read as ten.
+func parseCardinal(tokens []string) (*big.Int, bool) {
+	if len(tokens) == 1 && tokens[0] == "zero" {
+		return new(big.Int), true
+	}
+
+	total := new(big.Int)
+	var group int64
+	var prevScale *big.Int
+	last := kindStart
+	for i, t := range tokens {
+		next := ""
+		if i+1 < len(tokens) {
+			next = tokens[i+1]
+		}
+
+		if v, ok := units[t]; ok {
+			if last == kindUnit || last == kindTeen {
+				return nil, false
+			}
+			group += v
+			last = kindUnit
+			continue
+		}
+		if v, ok := teens[t]; ok {
+			if last == kindUnit || last == kindTeen || last == kindTens {
+				return nil, false
+			}
+			group += v
+			last = kindTeen
+			continue
+		}
+		if v, ok := tens[t]; ok {
+			if last == kindUnit || last == kindTeen || last == kindTens {
+				return nil, false
+			}
+			group += v
+			last = kindTens
+			continue
+		}
+		if scale, ok := scales[t]; ok {
+			if group == 0 || (prevScale != nil && scale.Cmp(prevScale) >= 0) {
+				return nil, false
+			}
+			total.Add(total, new(big.Int).Mul(big.NewInt(group), scale))
+			group = 0
+			prevScale = scale
+			last = kindScale
+			continue
+		}
+
+		switch t {
+		case "a", "an":
+			// only in "a hundred" or "a thousand"
+			if last != kindStart || (next != "hundred" && scales[next] == nil) {
+				return nil, false
+			}
+			group = 1
+			last = kindUnit
+		case "hundred":
+			if group == 0 || group >= 100 || last == kindHundred || last == kindScale {
+				return nil, false
+			}
+			group *= 100
+			last = kindHundred
+		case "and":
+			if (last != kindHundred && last != kindScale) || next == "" || next == "and" {
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+
+	if last == kindStart {
+		return nil, false
+	}
+	return total.Add(total, big.NewInt(group)), true
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+func indexOf(tokens []string, word string) int {
+	for i, t := range tokens {
+		if t == word {
+			return i
+		}
+	}
+	return -1
+}
+
+func lastIndexOf(tokens []string, word string) int {
+	for i := len(tokens) - 1; i >= 0; i-- {
+		if tokens[i] == word {
+			return i
+		}
+	}
+	return -1
+}
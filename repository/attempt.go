@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"database/sql"
+	"quiz_master/domain"
+	"strings"
+	"time"
+)
+
+const attemptColumns = "id,player,question_number,answer,correct,timed_out,score,hints_used,answered_at,duration_ms"
+
+type attemptRepository struct {
+	sqlRepository
+}
+
+func NewAttemptRepository(conn *sql.DB) domain.AttemptRepository {
+	return &attemptRepository{sqlRepository{conn, mysqlDialect{}}}
+}
+
+func NewPostgresAttemptRepository(conn *sql.DB) domain.AttemptRepository {
+	return &attemptRepository{sqlRepository{conn, postgresDialect{}}}
+}
+
+func NewSQLiteAttemptRepository(conn *sql.DB) domain.AttemptRepository {
+	return &attemptRepository{sqlRepository{conn, sqliteDialect{}}}
+}
+
+// Store inserts an attempt and charges it with the hints the player used
+// since their last attempt at the question.
+func (r *attemptRepository) Store(attempt *domain.Attempt) error {
+	var id int
+	err := r.transaction(func(tx *sql.Tx) error {
+		var err error
+		id, err = r.insert(tx, "INSERT INTO attempts(player, question_number, answer, correct, timed_out, score, hints_used, answered_at, duration_ms) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			attempt.Player, attempt.QuestionNumber, attempt.Answer, attempt.Correct, attempt.TimedOut, attempt.Score,
+			attempt.HintsUsed, attempt.AnsweredAt.UTC(), attempt.Duration.Milliseconds())
+		if err != nil {
+			return r.fail(err)
+		}
+		_, err = r.exec(tx, "UPDATE hint_uses SET attempt_id = ? WHERE player = ? AND question_number = ? AND attempt_id IS NULL",
+			id, attempt.Player, attempt.QuestionNumber)
+		return r.fail(err)
+	})
+	if err != nil {
+		return r.fail(err)
+	}
+
+	attempt.ID = id
+	return nil
+}
+
+func (r *attemptRepository) StoreHintUse(use *domain.HintUse) error {
+	id, err := r.insert(r.conn, "INSERT INTO hint_uses(player, question_number, hint, used_at) VALUES(?, ?, ?, ?)",
+		use.Player, use.QuestionNumber, use.Hint, use.UsedAt.UTC())
+	if err != nil {
+		return r.fail(err)
+	}
+
+	use.ID = id
+	return nil
+}
+
+// PendingHints counts the hints of a question player was shown since their
+// last attempt at it.
+func (r *attemptRepository) PendingHints(player string, questionNumber string) (int, error) {
+	var n int
+	err := r.conn.QueryRow(r.dialect.rebind("SELECT COUNT(*) FROM hint_uses WHERE player = ? AND question_number = ? AND attempt_id IS NULL"),
+		player, questionNumber).Scan(&n)
+	return n, r.fail(err)
+}
+
+// Fetch returns the attempts matching filter, oldest first.
+func (r *attemptRepository) Fetch(filter domain.AttemptFilter) ([]*domain.Attempt, error) {
+	where, args := []string{}, []interface{}{}
+	if filter.Player != "" {
+		where = append(where, "player = ?")
+		args = append(args, filter.Player)
+	}
+	if filter.QuestionNumber != "" {
+		where = append(where, "question_number = ?")
+		args = append(args, filter.QuestionNumber)
+	}
+	query := "SELECT " + attemptColumns + " FROM attempts"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	rows, err := r.conn.Query(r.dialect.rebind(query+" ORDER BY answered_at, id"), args...)
+	if err != nil {
+		return nil, r.fail(err)
+	}
+	defer rows.Close()
+
+	attempts := []*domain.Attempt{}
+	for rows.Next() {
+		a := &domain.Attempt{}
+		var durationMS int64
+		err := rows.Scan(&a.ID, &a.Player, &a.QuestionNumber, &a.Answer, &a.Correct, &a.TimedOut, &a.Score, &a.HintsUsed, &a.AnsweredAt, &durationMS)
+		if err != nil {
+			return nil, r.fail(err)
+		}
+		a.Duration = time.Duration(durationMS) * time.Millisecond
+		attempts = append(attempts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.fail(err)
+	}
+	return attempts, nil
+}
+
+// ScoreByQuestion sums up the attempts of player per question, in question
+// number order.
+func (r *attemptRepository) ScoreByQuestion(player string) ([]domain.QuestionScore, error) {
+	rows, err := r.conn.Query(r.dialect.rebind("SELECT question_number, COUNT(*), SUM(CASE WHEN correct THEN 1 ELSE 0 END), SUM(score) FROM attempts WHERE player = ? GROUP BY question_number ORDER BY "+
+		r.dialect.numeric("question_number")+", question_number"), player)
+	if err != nil {
+		return nil, r.fail(err)
+	}
+	defer rows.Close()
+
+	scores := []domain.QuestionScore{}
+	for rows.Next() {
+		s := domain.QuestionScore{}
+		if err := rows.Scan(&s.QuestionNumber, &s.Attempts, &s.Correct, &s.Score); err != nil {
+			return nil, r.fail(err)
+		}
+		scores = append(scores, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.fail(err)
+	}
+	return scores, nil
+}
+
+// leaderboardOrder ranks players by each leaderboard criterion, the later
+// columns break ties down to the player name so equal players always come
+// out in the same order.
+var leaderboardOrder = map[string]string{
+	domain.LeaderboardByAccuracy: "correct * 1.0 / attempts DESC, correct DESC, points DESC, player",
+	domain.LeaderboardByPoints:   "points DESC, correct * 1.0 / attempts DESC, streak DESC, player",
+	domain.LeaderboardByStreak:   "streak DESC, points DESC, correct * 1.0 / attempts DESC, player",
+}
+
+// Leaderboard ranks the players in the database. Streaks are the islands of
+// correct answers in each player's attempts: numbering the attempts of a
+// player and, separately, those of the player that are equally correct gives
+// the same difference throughout a run of correct answers.
+func (r *attemptRepository) Leaderboard(query domain.LeaderboardQuery) ([]domain.LeaderboardEntry, error) {
+	order, ok := leaderboardOrder[query.By]
+	if !ok {
+		return nil, domain.Invalid("by", "Unknown leaderboard order %q", query.By)
+	}
+	where, args := "", []interface{}{}
+	if !query.Since.IsZero() {
+		where = " WHERE answered_at >= ?"
+		args = append(args, query.Since.UTC(), query.Since.UTC())
+	}
+	args = append(args, query.Limit)
+
+	rows, err := r.conn.Query(r.dialect.rebind(`SELECT player, attempts, correct, points, streak FROM (
+  SELECT totals.player, totals.attempts, totals.correct, totals.points, COALESCE(streaks.streak, 0) AS streak
+  FROM (
+    SELECT player, COUNT(*) AS attempts, SUM(CASE WHEN correct THEN 1 ELSE 0 END) AS correct, SUM(score) AS points
+    FROM attempts`+where+`
+    GROUP BY player
+  ) totals
+  LEFT JOIN (
+    SELECT player, MAX(run) AS streak FROM (
+      SELECT player, COUNT(*) AS run FROM (
+        SELECT player, correct,
+          ROW_NUMBER() OVER (PARTITION BY player ORDER BY answered_at, id)
+          - ROW_NUMBER() OVER (PARTITION BY player, correct ORDER BY answered_at, id) AS island
+        FROM attempts`+where+`
+      ) numbered
+      WHERE correct
+      GROUP BY player, island
+    ) runs
+    GROUP BY player
+  ) streaks ON streaks.player = totals.player
+) standings
+ORDER BY `+order+`
+LIMIT ?`), args...)
+	if err != nil {
+		return nil, r.fail(err)
+	}
+	defer rows.Close()
+
+	entries := []domain.LeaderboardEntry{}
+	for rows.Next() {
+		e := domain.LeaderboardEntry{}
+		if err := rows.Scan(&e.Player, &e.Attempts, &e.Correct, &e.Points, &e.Streak); err != nil {
+			return nil, r.fail(err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.fail(err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"quiz_master/domain"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var attempt = &domain.Attempt{
+	Player:         "alice",
+	QuestionNumber: "1",
+	Answer:         "2",
+	Correct:        true,
+	Score:          1,
+	AnsweredAt:     time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC),
+	Duration:       1500 * time.Millisecond,
+}
+
+func TestAttemptStore_Success(t *testing.T) {
+	db, mock := NewMock()
+	attemptRepo := NewAttemptRepository(db)
+
+	mock.ExpectBegin()
+	query := regexp.QuoteMeta("INSERT INTO attempts(player, question_number, answer, correct, timed_out, score, hints_used, answered_at, duration_ms) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(attempt.Player, attempt.QuestionNumber, attempt.Answer, attempt.Correct, attempt.TimedOut, attempt.Score, attempt.HintsUsed, attempt.AnsweredAt, int64(1500)).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	prep = mock.ExpectPrepare(regexp.QuoteMeta("UPDATE hint_uses SET attempt_id = ? WHERE player = ? AND question_number = ? AND attempt_id IS NULL"))
+	prep.ExpectExec().
+		WithArgs(3, attempt.Player, attempt.QuestionNumber).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	a := *attempt
+	err := attemptRepo.Store(&a)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, a.ID)
+}
+
+func TestAttemptFetch_Filtered(t *testing.T) {
+	db, mock := NewMock()
+	attemptRepo := NewPostgresAttemptRepository(db)
+
+	query := regexp.QuoteMeta("SELECT id,player,question_number,answer,correct,timed_out,score,hints_used,answered_at,duration_ms FROM attempts WHERE player = $1 AND question_number = $2 ORDER BY answered_at, id")
+	rows := sqlmock.NewRows([]string{"id", "player", "question_number", "answer", "correct", "timed_out", "score", "hints_used", "answered_at", "duration_ms"}).
+		AddRow(3, attempt.Player, attempt.QuestionNumber, attempt.Answer, attempt.Correct, attempt.TimedOut, attempt.Score, attempt.HintsUsed, attempt.AnsweredAt, 1500)
+	mock.ExpectQuery(query).WithArgs("alice", "1").WillReturnRows(rows)
+
+	attempts, err := attemptRepo.Fetch(domain.AttemptFilter{Player: "alice", QuestionNumber: "1"})
+	assert.NoError(t, err)
+	expected := *attempt
+	expected.ID = 3
+	assert.Equal(t, []*domain.Attempt{&expected}, attempts)
+}
+
+func TestAttemptFetch_FailRowError(t *testing.T) {
+	db, mock := NewMock()
+	attemptRepo := NewAttemptRepository(db)
+
+	query := regexp.QuoteMeta("SELECT id,player,question_number,answer,correct,timed_out,score,hints_used,answered_at,duration_ms FROM attempts ORDER BY answered_at, id")
+	rows := sqlmock.NewRows([]string{"id", "player", "question_number", "answer", "correct", "timed_out", "score", "hints_used", "answered_at", "duration_ms"}).
+		AddRow(3, attempt.Player, attempt.QuestionNumber, attempt.Answer, attempt.Correct, attempt.TimedOut, attempt.Score, attempt.HintsUsed, attempt.AnsweredAt, 1500).
+		RowError(0, driver.ErrBadConn)
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	attempts, err := attemptRepo.Fetch(domain.AttemptFilter{})
+	assert.Nil(t, attempts)
+	assert.ErrorIs(t, err, domain.ErrUnavailable)
+}
+
+func TestSQLite_Attempts(t *testing.T) {
+	attemptRepo := NewSQLiteAttemptRepository(newSQLiteDB(t))
+
+	for i, a := range []domain.Attempt{
+		{Player: "alice", QuestionNumber: "2", Answer: "Rome", Correct: false, TimedOut: true, Score: 0},
+		{Player: "alice", QuestionNumber: "2", Answer: "Paris", Correct: true, Score: 1},
+		{Player: "alice", QuestionNumber: "1", Answer: "A", Correct: false, Score: 0.5},
+		{Player: "bob", QuestionNumber: "1", Answer: "A,C", Correct: true, Score: 1},
+		{Player: "alice", QuestionNumber: "10", Answer: "42", Correct: true, Score: 1},
+	} {
+		a.AnsweredAt = attempt.AnsweredAt.Add(time.Duration(i) * time.Minute)
+		a.Duration = time.Second
+		assert.NoError(t, attemptRepo.Store(&a))
+	}
+
+	attempts, err := attemptRepo.Fetch(domain.AttemptFilter{QuestionNumber: "2"})
+	assert.NoError(t, err)
+	assert.Len(t, attempts, 2)
+	assert.Equal(t, "Rome", attempts[0].Answer)
+	assert.Equal(t, time.Second, attempts[0].Duration)
+	assert.True(t, attempts[0].TimedOut)
+	assert.False(t, attempts[1].TimedOut)
+	assert.True(t, attempt.AnsweredAt.Equal(attempts[0].AnsweredAt))
+
+	attempts, err = attemptRepo.Fetch(domain.AttemptFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, attempts, 5)
+
+	scores, err := attemptRepo.ScoreByQuestion("alice")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.QuestionScore{
+		{QuestionNumber: "1", Attempts: 1, Correct: 0, Score: 0.5},
+		{QuestionNumber: "2", Attempts: 2, Correct: 1, Score: 1},
+		{QuestionNumber: "10", Attempts: 1, Correct: 1, Score: 1},
+	}, scores)
+}
+
+func TestSQLite_Leaderboard(t *testing.T) {
+	attemptRepo := NewSQLiteAttemptRepository(newSQLiteDB(t))
+
+	start := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	answers := map[string][]float64{
+		// scores in the order answered, 1 is correct
+		"alice": {1, 1, 0, 1, 1, 1},
+		"bob":   {1, 1, 1, 0.5},
+		"carol": {1, 1, 1, 0.5},
+		"dave":  {0, 0},
+	}
+	for player, scores := range answers {
+		for i, score := range scores {
+			assert.NoError(t, attemptRepo.Store(&domain.Attempt{
+				Player: player, QuestionNumber: "1", Answer: "x", Correct: score == 1, Score: score,
+				AnsweredAt: start.Add(time.Duration(i) * time.Minute),
+			}))
+		}
+	}
+
+	tests := []struct {
+		by      string
+		since   time.Time
+		limit   int
+		players []string
+		streaks []int
+	}{
+		{domain.LeaderboardByPoints, time.Time{}, 10, []string{"alice", "bob", "carol", "dave"}, []int{3, 3, 3, 0}},
+		{domain.LeaderboardByAccuracy, time.Time{}, 10, []string{"alice", "bob", "carol", "dave"}, []int{3, 3, 3, 0}},
+		{domain.LeaderboardByStreak, time.Time{}, 2, []string{"alice", "bob"}, []int{3, 3}},
+		{domain.LeaderboardByPoints, start.Add(3 * time.Minute), 10, []string{"alice", "bob", "carol"}, []int{3, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			entries, err := attemptRepo.Leaderboard(domain.LeaderboardQuery{By: tt.by, Since: tt.since, Limit: tt.limit})
+			assert.NoError(t, err)
+			players, streaks := []string{}, []int{}
+			for _, e := range entries {
+				players = append(players, e.Player)
+				streaks = append(streaks, e.Streak)
+			}
+			assert.Equal(t, tt.players, players)
+			assert.Equal(t, tt.streaks, streaks)
+		})
+	}
+
+	_, err := attemptRepo.Leaderboard(domain.LeaderboardQuery{By: "luck", Limit: 1})
+	assert.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestSQLite_HintUses(t *testing.T) {
+	attemptRepo := NewSQLiteAttemptRepository(newSQLiteDB(t))
+
+	for hint := 1; hint <= 2; hint++ {
+		assert.NoError(t, attemptRepo.StoreHintUse(&domain.HintUse{Player: "alice", QuestionNumber: "1", Hint: hint, UsedAt: attempt.AnsweredAt}))
+	}
+	assert.NoError(t, attemptRepo.StoreHintUse(&domain.HintUse{Player: "bob", QuestionNumber: "1", Hint: 1, UsedAt: attempt.AnsweredAt}))
+
+	pending, err := attemptRepo.PendingHints("alice", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pending)
+
+	a := domain.Attempt{Player: "alice", QuestionNumber: "1", Answer: "2", Correct: true, Score: 0.5, HintsUsed: 2, AnsweredAt: attempt.AnsweredAt}
+	assert.NoError(t, attemptRepo.Store(&a))
+
+	pending, err = attemptRepo.PendingHints("alice", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, pending)
+	pending, err = attemptRepo.PendingHints("bob", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pending)
+
+	attempts, err := attemptRepo.Fetch(domain.AttemptFilter{Player: "alice"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts[0].HintsUsed)
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Search snippets mark the matched words between highlightMark and are cut
+// down to about snippetWords words around them.
+const (
+	highlightMark = "**"
+	snippetWords  = 15
+)
+
+// dialect covers what differs between the SQL backends, the queries
+// themselves are written once with ? placeholders.
+type dialect interface {
+	// rebind rewrites the ? placeholders of query into the backend's syntax.
+	rebind(query string) string
+	// returningID reports whether inserted ids come back through RETURNING
+	// instead of sql.Result.LastInsertId.
+	returningID() bool
+	// isUniqueViolation reports whether err was caused by a unique constraint.
+	isUniqueViolation(err error) bool
+	// isUnavailable reports whether err means the database cannot be
+	// reached or used at all, beyond the network errors all backends share.
+	isUnavailable(err error) bool
+	// numeric casts a text column holding numbers so that it sorts by value.
+	numeric(column string) string
+	// searchHits returns a query listing the question_id, score and snippet
+	// of the questions matching every one of words, with its args.
+	searchHits(words []string) (string, []interface{})
+	// highlight finishes a snippet returned by searchHits, for the backends
+	// that cannot highlight words themselves.
+	highlight(snippet string, words []string) string
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) rebind(query string) string {
+	return query
+}
+
+func (mysqlDialect) returningID() bool {
+	return false
+}
+
+func (mysqlDialect) isUniqueViolation(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
+
+// isUnavailable covers a dropped connection, too many connections (1040),
+// a refused login (1045) and an unknown database (1049).
+func (mysqlDialect) isUnavailable(err error) bool {
+	if errors.Is(err, mysql.ErrInvalidConn) {
+		return true
+	}
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	switch mysqlErr.Number {
+	case 1040, 1045, 1049:
+		return true
+	}
+	return false
+}
+
+func (mysqlDialect) numeric(column string) string {
+	return "CAST(" + column + " AS DECIMAL(65,10))"
+}
+
+// searchHits uses the FULLTEXT index in boolean mode, each word quoted and
+// required so that none of them is read as an operator.
+func (mysqlDialect) searchHits(words []string) (string, []interface{}) {
+	required := make([]string, len(words))
+	for i, w := range words {
+		required[i] = `+"` + strings.ReplaceAll(w, `"`, "") + `"`
+	}
+	against := strings.Join(required, " ")
+	return "SELECT id AS question_id, MATCH(question, answer, explanation) AGAINST (? IN BOOLEAN MODE) AS score, CONCAT_WS(' | ', question, answer, NULLIF(explanation, '')) AS snippet FROM questions WHERE MATCH(question, answer, explanation) AGAINST (? IN BOOLEAN MODE)",
+		[]interface{}{against, against}
+}
+
+// highlight marks words in snippet, MySQL has no function for it, and keeps
+// the snippetWords words starting a little before the first of them.
+func (mysqlDialect) highlight(snippet string, words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = regexp.QuoteMeta(w)
+	}
+	match := regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`)
+
+	fields := strings.Fields(snippet)
+	start := 0
+	for i, f := range fields {
+		if match.MatchString(f) {
+			start = i - snippetWords/3
+			break
+		}
+	}
+	if start > len(fields)-snippetWords {
+		start = len(fields) - snippetWords
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetWords
+	if end > len(fields) {
+		end = len(fields)
+	}
+	cut := strings.Join(fields[start:end], " ")
+	if start > 0 {
+		cut = "…" + cut
+	}
+	if end < len(fields) {
+		cut += "…"
+	}
+	return match.ReplaceAllString(cut, highlightMark+"$1"+highlightMark)
+}
@@ -2,92 +2,587 @@ package repository
 
 import (
 	"database/sql"
-	"fmt"
+	"errors"
 	"quiz_master/domain"
+	"strings"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
+// questionColumns are selected by every query returning questions, in the
+// order scanQuestion reads them.
+const questionColumns = "id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation"
+
+// insertQuestion and updateQuestion write a question, with the args of
+// insertArgs and updateArgs.
+const (
+	insertQuestion = "INSERT INTO questions(number, question, answer, answer_type, lang, grading_rule, grading_tolerance, grading_min, grading_max, fuzzy_threshold, time_limit_ms, explanation) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	updateQuestion = "UPDATE questions SET number = ?, question = ?, answer = ?, answer_type = ?, lang = ?, grading_rule = ?, grading_tolerance = ?, grading_min = ?, grading_max = ?, fuzzy_threshold = ?, time_limit_ms = ?, explanation = ? WHERE id = ? AND deleted_at IS NULL"
+)
+
+func insertArgs(q *domain.Question) []interface{} {
+	return []interface{}{q.Number, q.Question, q.Answer, q.AnswerType, q.Lang,
+		q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation}
+}
+
+func updateArgs(q *domain.Question) []interface{} {
+	return append(insertArgs(q), q.ID)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
 type questionRepository struct {
-	conn *sql.DB
+	sqlRepository
 }
 
 func NewQuestionRepository(conn *sql.DB) domain.QuestionRepository {
-	return &questionRepository{conn}
+	return &questionRepository{sqlRepository{conn, mysqlDialect{}}}
 }
 
-func (r questionRepository) GetAll() ([]*domain.Question, error) {
+// GetAll returns a page of the questions matching options.Filter, sorted
+// and paged in the query itself.
+func (r questionRepository) GetAll(options domain.QuestionListOptions) ([]*domain.Question, error) {
 	questions := []*domain.Question{}
-	rows, err := r.conn.Query("SELECT number,question,answer FROM questions WHERE deleted_at IS NULL ORDER BY number ASC")
+	where, args := filterQuestions(options.Filter)
+	query := "SELECT " + questionColumns + " FROM questions WHERE " + where + " ORDER BY " + r.orderBy(options.Sort, options.Desc)
+	if options.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, options.Limit, options.Offset)
+	}
+	rows, err := r.conn.Query(r.dialect.rebind(query), args...)
 	if err != nil {
-		return nil, err
+		return nil, r.fail(err)
 	}
 	defer rows.Close()
 	for rows.Next() {
 		question := &domain.Question{}
-		err := rows.Scan(&question.Number, &question.Question, &question.Answer)
+		err := scanQuestion(rows, question)
 		if err != nil {
-			return nil, err
+			return nil, r.fail(err)
 		}
 		questions = append(questions, question)
 	}
-	return questions, nil
+	if err := rows.Err(); err != nil {
+		return nil, r.fail(err)
+	}
+	return questions, r.loadRelations(questions)
 }
 
-func (r *questionRepository) Store(question *domain.Question) error {
+// Count returns how many questions match filter, on every page.
+func (r questionRepository) Count(filter domain.QuestionFilter) (int, error) {
+	where, args := filterQuestions(filter)
+	var total int
+	err := r.conn.QueryRow(r.dialect.rebind("SELECT COUNT(*) FROM questions WHERE "+where), args...).Scan(&total)
+	return total, r.fail(err)
+}
 
-	stmt, err := r.conn.Prepare("INSERT INTO questions(number, question, answer) VALUES(?, ?, ?)")
-	if err != nil {
-		return err
+// filterQuestions returns the WHERE condition keeping the questions that
+// are not in the trash and match filter, with its args.
+func filterQuestions(filter domain.QuestionFilter) (string, []interface{}) {
+	where := "deleted_at IS NULL"
+	args := []interface{}{}
+	if len(filter.Tags) > 0 {
+		where += " AND id IN (SELECT question_tags.question_id FROM question_tags JOIN tags ON tags.id = question_tags.tag_id WHERE tags.name IN (" + placeholders(len(filter.Tags)) + "))"
+		for _, tag := range filter.Tags {
+			args = append(args, tag)
+		}
 	}
+	return where, args
+}
 
-	res, err := stmt.Exec(question.Number, question.Question, question.Answer)
-	if err != nil {
-		return err
+// orderBy returns the ORDER BY clause of sort, numbers compared as numbers
+// and ties broken by id.
+func (r questionRepository) orderBy(sort string, desc bool) string {
+	dir := " ASC"
+	if desc {
+		dir = " DESC"
+	}
+	number := r.dialect.numeric("number") + dir
+	switch sort {
+	case domain.SortCreated:
+		return "created_at" + dir + ", id" + dir
+	case domain.SortQuestion:
+		return "question" + dir + ", " + number + ", id" + dir
+	default:
+		return number + ", id" + dir
 	}
+}
 
-	rows, _ := res.RowsAffected()
-	if rows != 1 {
-		return fmt.Errorf("expected to affect 1 row, affected %d", rows)
+func (r *questionRepository) Store(question *domain.Question) error {
+	var id int
+	err := r.transaction(func(tx *sql.Tx) error {
+		var err error
+		id, err = r.insert(tx, insertQuestion, insertArgs(question)...)
+		if err != nil {
+			return r.fail(err)
+		}
+		if err := r.storeChoices(tx, id, question.Choices); err != nil {
+			return r.fail(err)
+		}
+		return r.storeHints(tx, id, question.Hints)
+	})
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		return domain.Errorf(domain.ErrAlreadyExists, "Question no %s already existed!", question.Number)
+	}
+	if err != nil {
+		return r.fail(err)
 	}
 
+	question.ID = id
 	return nil
 }
 
+// Upsert stores question under its number, overwriting the question that
+// has it or inserting a new one, and tells whether it inserted. Aliases and
+// tags of an overwritten question are kept. When a concurrent insert takes
+// the number first, the insert fails and the overwrite is tried again.
+func (r *questionRepository) Upsert(question *domain.Question) (bool, error) {
+	created, err := r.upsert(question)
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		created, err = r.upsert(question)
+	}
+	// the number still taken by no question is taken by a trashed one
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		return false, domain.Errorf(domain.ErrAlreadyExists, "Question no %s is in the trash, restore or purge it first!", question.Number)
+	}
+	return created, err
+}
+
+func (r *questionRepository) upsert(question *domain.Question) (bool, error) {
+	created := false
+	stored := *question
+	err := r.transaction(func(tx *sql.Tx) error {
+		err := tx.QueryRow(r.dialect.rebind("SELECT id FROM questions WHERE number = ? AND deleted_at IS NULL"), question.Number).Scan(&stored.ID)
+		if err == sql.ErrNoRows {
+			created = true
+			stored.ID, err = r.insert(tx, insertQuestion, insertArgs(&stored)...)
+			if err != nil {
+				return r.fail(err)
+			}
+			if err := r.storeChoices(tx, stored.ID, stored.Choices); err != nil {
+				return r.fail(err)
+			}
+			return r.storeHints(tx, stored.ID, stored.Hints)
+		}
+		if err != nil {
+			return r.fail(err)
+		}
+
+		// exec rather than execOne, MySQL counts an unchanged row as not
+		// affected
+		if _, err := r.exec(tx, updateQuestion, updateArgs(&stored)...); err != nil {
+			return r.fail(err)
+		}
+		return r.replaceRelations(tx, &stored)
+	})
+	if err != nil {
+		return false, r.fail(err)
+	}
+
+	question.ID = stored.ID
+	return created, nil
+}
+
 func (r *questionRepository) GetByNumber(number string) (domain.Question, error) {
 	q := domain.Question{}
-	stmt, err := r.conn.Prepare("SELECT id,number,question,answer FROM questions WHERE number = ?")
+	stmt, err := r.conn.Prepare(r.dialect.rebind("SELECT " + questionColumns + " FROM questions WHERE number = ? AND deleted_at IS NULL"))
 	if err != nil {
-		return q, err
+		return q, r.fail(err)
 	}
 	defer stmt.Close()
-	err = stmt.QueryRow(number).Scan(&q.ID, &q.Number, &q.Question, &q.Answer)
+	err = scanQuestion(stmt.QueryRow(number), &q)
 	if err != nil && err != sql.ErrNoRows {
-		return q, err
+		return q, r.fail(err)
 	}
 
 	if err == sql.ErrNoRows {
-		return q, fmt.Errorf("Question not found")
+		return q, domain.Errorf(domain.ErrNotFound, "Question not found")
 	}
 
-	return q, nil
+	return q, r.loadRelations([]*domain.Question{&q})
 }
 
+// Update overwrites the question stored under question.ID, including its
+// number, and replaces its choices and hints.
+func (r *questionRepository) Update(question *domain.Question) error {
+	err := r.transaction(func(tx *sql.Tx) error {
+		var id int
+		err := tx.QueryRow(r.dialect.rebind("SELECT id FROM questions WHERE id = ? AND deleted_at IS NULL"), question.ID).Scan(&id)
+		if err == sql.ErrNoRows {
+			return errNoRow
+		}
+		if err != nil {
+			return r.fail(err)
+		}
+
+		// exec rather than execOne, MySQL counts an unchanged row as not
+		// affected, as when only the hints change
+		if _, err := r.exec(tx, updateQuestion, updateArgs(question)...); err != nil {
+			return r.fail(err)
+		}
+		return r.replaceRelations(tx, question)
+	})
+	switch {
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return domain.Errorf(domain.ErrAlreadyExists, "Question no %s already existed!", question.Number)
+	case errors.Is(err, errNoRow):
+		return domain.Errorf(domain.ErrNotFound, "Question not found")
+	}
+	return r.fail(err)
+}
+
+// Destroy moves a question to the trash, it stays there until Restore or
+// Purge.
 func (r *questionRepository) Destroy(number string) error {
-	stmt, err := r.conn.Prepare("DELETE FROM questions WHERE number = ? AND deleted_at IS NULL")
+	err := r.execOne(r.conn, "UPDATE questions SET deleted_at = ? WHERE number = ? AND deleted_at IS NULL",
+		time.Now().UTC(), number)
+	if errors.Is(err, errNoRow) {
+		return domain.Errorf(domain.ErrNotFound, "Question not found")
+	}
+	return err
+}
+
+func (r *questionRepository) GetTrashed() ([]*domain.Question, error) {
+	questions := []*domain.Question{}
+	rows, err := r.conn.Query("SELECT " + questionColumns + ",deleted_at FROM questions WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC")
 	if err != nil {
-		return err
+		return nil, r.fail(err)
 	}
+	defer rows.Close()
+	for rows.Next() {
+		question := &domain.Question{}
+		err := scanQuestion(rows, question, &question.DeletedAt)
+		if err != nil {
+			return nil, r.fail(err)
+		}
+		questions = append(questions, question)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.fail(err)
+	}
+	return questions, nil
+}
 
-	res, err := stmt.Exec(number)
+func (r *questionRepository) GetTrashedByNumber(number string) (domain.Question, error) {
+	q := domain.Question{}
+	stmt, err := r.conn.Prepare(r.dialect.rebind("SELECT " + questionColumns + ",deleted_at FROM questions WHERE number = ? AND deleted_at IS NOT NULL"))
 	if err != nil {
-		return err
+		return q, r.fail(err)
+	}
+	defer stmt.Close()
+	err = scanQuestion(stmt.QueryRow(number), &q, &q.DeletedAt)
+	if err == sql.ErrNoRows {
+		return q, domain.Errorf(domain.ErrNotFound, "Question not found in trash")
+	}
+
+	return q, r.fail(err)
+}
+
+func (r *questionRepository) Restore(number string) error {
+	err := r.execOne(r.conn, "UPDATE questions SET deleted_at = NULL WHERE number = ? AND deleted_at IS NOT NULL", number)
+	if errors.Is(err, errNoRow) {
+		return domain.Errorf(domain.ErrNotFound, "Question not found in trash")
+	}
+	return err
+}
+
+// Purge permanently deletes the questions trashed before deletedBefore, after
+// which their numbers can be used again.
+func (r *questionRepository) Purge(deletedBefore time.Time) (int64, error) {
+	return r.exec(r.conn, "DELETE FROM questions WHERE deleted_at IS NOT NULL AND deleted_at <= ?", deletedBefore.UTC())
+}
+
+func (r *questionRepository) AddAlias(questionID int, alias string) error {
+	err := r.execOne(r.conn, "INSERT INTO question_aliases(question_id, alias) VALUES(?, ?)", questionID, alias)
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		return domain.Errorf(domain.ErrAlreadyExists, "Alias %s already exists", alias)
+	}
+	return err
+}
+
+func (r *questionRepository) RemoveAlias(questionID int, alias string) error {
+	err := r.execOne(r.conn, "DELETE FROM question_aliases WHERE question_id = ? AND alias = ?", questionID, alias)
+	if errors.Is(err, errNoRow) {
+		return domain.Errorf(domain.ErrNotFound, "Alias %s not found", alias)
+	}
+	return err
+}
+
+// AddTags tags the question stored under questionID, creating the tags that
+// do not exist yet.
+func (r *questionRepository) AddTags(questionID int, tags []string) error {
+	return r.transaction(func(tx *sql.Tx) error {
+		for _, tag := range tags {
+			var tagID int
+			err := tx.QueryRow(r.dialect.rebind("SELECT id FROM tags WHERE name = ?"), tag).Scan(&tagID)
+			if err == sql.ErrNoRows {
+				tagID, err = r.insert(tx, "INSERT INTO tags(name) VALUES(?)", tag)
+			}
+			if err != nil {
+				return r.fail(err)
+			}
+			err = r.execOne(tx, "INSERT INTO question_tags(question_id, tag_id) VALUES(?, ?)", questionID, tagID)
+			if err != nil {
+				return r.fail(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *questionRepository) RemoveTags(questionID int, tags []string) error {
+	args := []interface{}{questionID}
+	for _, tag := range tags {
+		args = append(args, tag)
+	}
+	_, err := r.exec(r.conn, "DELETE FROM question_tags WHERE question_id = ? AND tag_id IN (SELECT id FROM tags WHERE name IN ("+placeholders(len(tags))+"))", args...)
+	return r.fail(err)
+}
+
+// CountTags returns every tag of a question that is not in the trash, with
+// how many such questions have it, by name.
+func (r *questionRepository) CountTags() ([]domain.TagCount, error) {
+	rows, err := r.conn.Query("SELECT tags.name, COUNT(*) FROM tags JOIN question_tags ON question_tags.tag_id = tags.id JOIN questions ON questions.id = question_tags.question_id WHERE questions.deleted_at IS NULL GROUP BY tags.name ORDER BY tags.name")
+	if err != nil {
+		return nil, r.fail(err)
+	}
+	defer rows.Close()
+
+	counts := []domain.TagCount{}
+	for rows.Next() {
+		c := domain.TagCount{}
+		if err := rows.Scan(&c.Tag, &c.Questions); err != nil {
+			return nil, r.fail(err)
+		}
+		counts = append(counts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.fail(err)
+	}
+	return counts, nil
+}
+
+// Search ranks the questions matching query with the backend's own full
+// text search, see dialect.searchHits.
+func (r *questionRepository) Search(query domain.SearchQuery) ([]domain.SearchResult, error) {
+	words := strings.Fields(query.Terms)
+	hits, args := r.dialect.searchHits(words)
+	stmt := "SELECT " + questionColumns + ",deleted_at,hits.score,hits.snippet FROM questions JOIN (" + hits + ") hits ON hits.question_id = questions.id"
+	if !query.IncludeDeleted {
+		stmt += " WHERE deleted_at IS NULL"
+	}
+	stmt += " ORDER BY hits.score DESC, " + r.dialect.numeric("number") + " ASC"
+	if query.Limit > 0 {
+		stmt += " LIMIT ?"
+		args = append(args, query.Limit)
+	}
+
+	rows, err := r.conn.Query(r.dialect.rebind(stmt), args...)
+	if err != nil {
+		return nil, r.fail(err)
+	}
+	defer rows.Close()
+
+	results := []domain.SearchResult{}
+	for rows.Next() {
+		result := domain.SearchResult{}
+		err := scanQuestion(rows, &result.Question, &result.Question.DeletedAt, &result.Score, &result.Snippet)
+		if err != nil {
+			return nil, r.fail(err)
+		}
+		result.Snippet = r.dialect.highlight(result.Snippet, words)
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.fail(err)
+	}
+	return results, nil
+}
+
+// replaceRelations replaces the choices and hints of question with its own.
+func (r *questionRepository) replaceRelations(tx *sql.Tx, question *domain.Question) error {
+	if _, err := r.exec(tx, "DELETE FROM question_choices WHERE question_id = ?", question.ID); err != nil {
+		return r.fail(err)
+	}
+	if err := r.storeChoices(tx, question.ID, question.Choices); err != nil {
+		return r.fail(err)
+	}
+	if _, err := r.exec(tx, "DELETE FROM question_hints WHERE question_id = ?", question.ID); err != nil {
+		return r.fail(err)
 	}
+	return r.storeHints(tx, question.ID, question.Hints)
+}
 
-	rows, _ := res.RowsAffected()
-	if rows != 1 {
-		return fmt.Errorf("expected to affect 1 row, affected %d", rows)
+// storeChoices inserts the choices of the question stored under questionID.
+func (r *questionRepository) storeChoices(db preparer, questionID int, choices []domain.Choice) error {
+	for _, c := range choices {
+		err := r.execOne(db, "INSERT INTO question_choices(question_id, label, content, correct) VALUES(?, ?, ?, ?)",
+			questionID, c.Label, c.Text, c.Correct)
+		if err != nil {
+			return r.fail(err)
+		}
 	}
+	return nil
+}
 
+// storeHints inserts the hints of the question stored under questionID, in
+// order.
+func (r *questionRepository) storeHints(db preparer, questionID int, hints []string) error {
+	for i, h := range hints {
+		err := r.execOne(db, "INSERT INTO question_hints(question_id, position, content) VALUES(?, ?, ?)",
+			questionID, i+1, h)
+		if err != nil {
+			return r.fail(err)
+		}
+	}
+	return nil
+}
+
+// loadRelations fills in what is stored in the child tables of questions.
+func (r *questionRepository) loadRelations(questions []*domain.Question) error {
+	if err := r.loadChoices(questions); err != nil {
+		return r.fail(err)
+	}
+	if err := r.loadAliases(questions); err != nil {
+		return r.fail(err)
+	}
+	if err := r.loadHints(questions); err != nil {
+		return r.fail(err)
+	}
+	return r.loadTags(questions)
+}
+
+// loadTags fills in the tags of questions, by name, with a single query.
+func (r *questionRepository) loadTags(questions []*domain.Question) error {
+	byID := map[int]*domain.Question{}
+	ids := []interface{}{}
+	for _, q := range questions {
+		byID[q.ID] = q
+		ids = append(ids, q.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := r.conn.Query(r.dialect.rebind("SELECT question_tags.question_id,tags.name FROM question_tags JOIN tags ON tags.id = question_tags.tag_id WHERE question_tags.question_id IN ("+placeholders(len(ids))+") ORDER BY question_tags.question_id, tags.name"), ids...)
+	if err != nil {
+		return r.fail(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var questionID int
+		var tag string
+		if err := rows.Scan(&questionID, &tag); err != nil {
+			return r.fail(err)
+		}
+		byID[questionID].Tags = append(byID[questionID].Tags, tag)
+	}
+	return r.fail(rows.Err())
+}
+
+// loadHints fills in the hints of questions, in order, with a single query.
+func (r *questionRepository) loadHints(questions []*domain.Question) error {
+	byID := map[int]*domain.Question{}
+	ids := []interface{}{}
+	for _, q := range questions {
+		byID[q.ID] = q
+		ids = append(ids, q.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := r.conn.Query(r.dialect.rebind("SELECT question_id,content FROM question_hints WHERE question_id IN ("+placeholders(len(ids))+") ORDER BY question_id, position"), ids...)
+	if err != nil {
+		return r.fail(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var questionID int
+		var hint string
+		if err := rows.Scan(&questionID, &hint); err != nil {
+			return r.fail(err)
+		}
+		byID[questionID].Hints = append(byID[questionID].Hints, hint)
+	}
+	return r.fail(rows.Err())
+}
+
+// loadAliases fills in the aliases of questions with a single query.
+func (r *questionRepository) loadAliases(questions []*domain.Question) error {
+	byID := map[int]*domain.Question{}
+	ids := []interface{}{}
+	for _, q := range questions {
+		byID[q.ID] = q
+		ids = append(ids, q.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := r.conn.Query(r.dialect.rebind("SELECT question_id,alias FROM question_aliases WHERE question_id IN ("+placeholders(len(ids))+") ORDER BY question_id, id"), ids...)
+	if err != nil {
+		return r.fail(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var questionID int
+		var alias string
+		if err := rows.Scan(&questionID, &alias); err != nil {
+			return r.fail(err)
+		}
+		byID[questionID].Aliases = append(byID[questionID].Aliases, alias)
+	}
+	return r.fail(rows.Err())
+}
+
+// loadChoices fills in the choices of the multiple choice questions among
+// questions with a single query.
+func (r *questionRepository) loadChoices(questions []*domain.Question) error {
+	byID := map[int]*domain.Question{}
+	ids := []interface{}{}
+	for _, q := range questions {
+		if q.AnswerType == domain.AnswerTypeChoice {
+			byID[q.ID] = q
+			ids = append(ids, q.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := r.conn.Query(r.dialect.rebind("SELECT question_id,label,content,correct FROM question_choices WHERE question_id IN ("+placeholders(len(ids))+") ORDER BY question_id, label"), ids...)
+	if err != nil {
+		return r.fail(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var questionID int
+		c := domain.Choice{}
+		if err := rows.Scan(&questionID, &c.Label, &c.Text, &c.Correct); err != nil {
+			return r.fail(err)
+		}
+		byID[questionID].Choices = append(byID[questionID].Choices, c)
+	}
+	return r.fail(rows.Err())
+}
+
+// placeholders returns n comma separated bind variables for an IN clause.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// scanQuestion reads questionColumns into q, followed by any extra columns.
+func scanQuestion(row scanner, q *domain.Question, extra ...interface{}) error {
+	var limit int64
+	dest := []interface{}{&q.ID, &q.Number, &q.Question, &q.Answer, &q.AnswerType, &q.Lang,
+		&q.Grading.Rule, &q.Grading.Tolerance, &q.Grading.Min, &q.Grading.Max, &q.Fuzzy, &limit, &q.Explanation}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	q.TimeLimit = time.Duration(limit) * time.Millisecond
 	return nil
 }
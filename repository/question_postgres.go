@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"quiz_master/domain"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// uniqueViolation is the SQLSTATE Postgres reports for duplicate keys.
+const uniqueViolation = "23505"
+
+type postgresDialect struct{}
+
+func (postgresDialect) rebind(query string) string {
+	var b strings.Builder
+	n := 0
+	for _, c := range query {
+		if c != '?' {
+			b.WriteRune(c)
+			continue
+		}
+		n++
+		b.WriteString("$" + strconv.Itoa(n))
+	}
+	return b.String()
+}
+
+func (postgresDialect) returningID() bool {
+	return true
+}
+
+func (postgresDialect) isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
+// isUnavailable covers the connection exceptions (class 08), a refused
+// login (class 28), an unknown database and a server not accepting
+// connections yet.
+func (postgresDialect) isUnavailable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code.Class() {
+	case "08", "28":
+		return true
+	}
+	return pqErr.Code == "3D000" || pqErr.Code == "57P03"
+}
+
+func (postgresDialect) numeric(column string) string {
+	return "CAST(" + column + " AS numeric)"
+}
+
+// searchHits ranks the matches of the search_vector column, whose ts_headline
+// highlights the words.
+func (postgresDialect) searchHits(words []string) (string, []interface{}) {
+	terms := strings.Join(words, " ")
+	return "SELECT id AS question_id, ts_rank(search_vector, plainto_tsquery('simple', ?)) AS score, ts_headline('simple', concat_ws(' | ', question, answer, NULLIF(explanation, '')), plainto_tsquery('simple', ?), 'StartSel=" + highlightMark + ", StopSel=" + highlightMark + ", MaxWords=" + strconv.Itoa(snippetWords) + ", MinWords=5') AS snippet FROM questions WHERE search_vector @@ plainto_tsquery('simple', ?)",
+		[]interface{}{terms, terms, terms}
+}
+
+func (postgresDialect) highlight(snippet string, words []string) string {
+	return snippet
+}
+
+func NewPostgresQuestionRepository(conn *sql.DB) domain.QuestionRepository {
+	return &questionRepository{sqlRepository{conn, postgresDialect{}}}
+}
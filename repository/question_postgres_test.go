@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"quiz_master/domain"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresRebind(t *testing.T) {
+	query := postgresDialect{}.rebind("SELECT id FROM questions WHERE number = ? AND answer = ?")
+	assert.Equal(t, "SELECT id FROM questions WHERE number = $1 AND answer = $2", query)
+}
+
+func TestPostgresStore_Success(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewPostgresQuestionRepository(db)
+
+	mock.ExpectBegin()
+	query := regexp.QuoteMeta("INSERT INTO questions(number, question, answer, answer_type, lang, grading_rule, grading_tolerance, grading_min, grading_max, fuzzy_threshold, time_limit_ms, explanation) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().
+		WithArgs(q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+
+	question := *q
+	err := questionRepo.Store(&question)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, question.ID)
+}
+
+func TestPostgresStore_FailUniqueViolation(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewPostgresQuestionRepository(db)
+
+	mock.ExpectBegin()
+	query := regexp.QuoteMeta("INSERT INTO questions(number, question, answer, answer_type, lang, grading_rule, grading_tolerance, grading_min, grading_max, fuzzy_threshold, time_limit_ms, explanation) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().
+		WithArgs(q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation).
+		WillReturnError(&pq.Error{Code: uniqueViolation})
+	mock.ExpectRollback()
+
+	err := questionRepo.Store(q)
+	assert.Error(t, err)
+	assert.Equal(t, "Question no 1 already existed!", err.Error())
+}
+
+func TestPostgresStore_FailErrorOnQuery(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewPostgresQuestionRepository(db)
+
+	mock.ExpectBegin()
+	query := regexp.QuoteMeta("INSERT INTO questions(number, question, answer, answer_type, lang, grading_rule, grading_tolerance, grading_min, grading_max, fuzzy_threshold, time_limit_ms, explanation) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().
+		WithArgs(q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation).
+		WillReturnError(fmt.Errorf("some error"))
+	mock.ExpectRollback()
+
+	err := questionRepo.Store(q)
+	assert.Error(t, err)
+	assert.Equal(t, "some error", err.Error())
+}
+
+func TestPostgresGetByNumber_Success(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewPostgresQuestionRepository(db)
+
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation FROM questions WHERE number = $1 AND deleted_at IS NULL")
+	prep := mock.ExpectPrepare(query)
+
+	rows := sqlmock.NewRows([]string{"id", "number", "question", "answer", "answer_type", "lang", "grading_rule", "grading_tolerance", "grading_min", "grading_max", "fuzzy_threshold", "time_limit_ms", "explanation"}).
+		AddRow(q.ID, q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation)
+	prep.ExpectQuery().WithArgs(q.Number).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT question_id,alias FROM question_aliases WHERE question_id IN ($1) ORDER BY question_id, id")).
+		WillReturnRows(sqlmock.NewRows([]string{"question_id", "alias"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT question_id,content FROM question_hints WHERE question_id IN ($1) ORDER BY question_id, position")).
+		WillReturnRows(sqlmock.NewRows([]string{"question_id", "content"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT question_tags.question_id,tags.name FROM question_tags JOIN tags ON tags.id = question_tags.tag_id WHERE question_tags.question_id IN ($1) ORDER BY question_tags.question_id, tags.name")).
+		WillReturnRows(sqlmock.NewRows([]string{"question_id", "name"}))
+
+	question, err := questionRepo.GetByNumber(q.Number)
+	assert.NoError(t, err)
+	assert.Equal(t, *q, question)
+}
+
+func TestPostgresGetByNumber_FailRecordNotFound(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewPostgresQuestionRepository(db)
+
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation FROM questions WHERE number = $1 AND deleted_at IS NULL")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(q.Number).WillReturnError(sql.ErrNoRows)
+
+	question, err := questionRepo.GetByNumber(q.Number)
+	assert.Empty(t, question)
+	assert.Error(t, err)
+}
+
+func TestPostgresDestroy_Success(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewPostgresQuestionRepository(db)
+
+	query := regexp.QuoteMeta("UPDATE questions SET deleted_at = $1 WHERE number = $2 AND deleted_at IS NULL")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(sqlmock.AnyArg(), q.Number).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := questionRepo.Destroy(q.Number)
+	assert.NoError(t, err)
+}
+
+func TestPostgresSearch_IncludeDeleted(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewPostgresQuestionRepository(db)
+
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation,deleted_at,hits.score,hits.snippet FROM questions JOIN (SELECT id AS question_id, ts_rank(search_vector, plainto_tsquery('simple', $1)) AS score, ts_headline('simple', concat_ws(' | ', question, answer, NULLIF(explanation, '')), plainto_tsquery('simple', $2), 'StartSel=**, StopSel=**, MaxWords=15, MinWords=5') AS snippet FROM questions WHERE search_vector @@ plainto_tsquery('simple', $3)) hits ON hits.question_id = questions.id ORDER BY hits.score DESC, CAST(number AS numeric) ASC")
+	mock.ExpectQuery(query).WithArgs("lorem", "lorem", "lorem").WillReturnError(fmt.Errorf("some error"))
+
+	results, err := questionRepo.Search(domain.SearchQuery{Terms: " lorem ", IncludeDeleted: true})
+	assert.Nil(t, results)
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"quiz_master/domain"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) rebind(query string) string {
+	return query
+}
+
+func (sqliteDialect) returningID() bool {
+	return false
+}
+
+func (sqliteDialect) isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
+
+// isUnavailable covers a database file that cannot be opened or is not a
+// database.
+func (sqliteDialect) isUnavailable(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && (sqliteErr.Code == sqlite3.ErrCantOpen || sqliteErr.Code == sqlite3.ErrNotADB)
+}
+
+func (sqliteDialect) numeric(column string) string {
+	return "CAST(" + column + " AS REAL)"
+}
+
+// searchHits matches the questions_fts table, an FTS4 index as the driver
+// is built without FTS5. FTS4 has no ranking of its own, the score is BM25
+// from the statistics matchinfo gives, see database.SQLiteDriver. Each word
+// is matched as a phrase, without the quotes FTS4 has no way to escape.
+func (sqliteDialect) searchHits(words []string) (string, []interface{}) {
+	phrases := make([]string, len(words))
+	for i, w := range words {
+		phrases[i] = `"` + strings.ReplaceAll(w, `"`, "") + `"`
+	}
+	return "SELECT docid AS question_id, okapi_bm25(matchinfo(questions_fts, 'pcnalx')) AS score, snippet(questions_fts, '" + highlightMark + "', '" + highlightMark + "', '…', -1, " + strconv.Itoa(snippetWords) + ") AS snippet FROM questions_fts WHERE questions_fts MATCH ?",
+		[]interface{}{strings.Join(phrases, " ")}
+}
+
+func (sqliteDialect) highlight(snippet string, words []string) string {
+	return snippet
+}
+
+// NewSQLiteQuestionRepository stores questions in a single file database,
+// its schema comes from database.Migrator like for every other driver.
+func NewSQLiteQuestionRepository(conn *sql.DB) domain.QuestionRepository {
+	return &questionRepository{sqlRepository{conn, sqliteDialect{}}}
+}
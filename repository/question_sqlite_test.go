@@ -0,0 +1,449 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"quiz_master/database"
+	"quiz_master/domain"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func NewSQLite(t *testing.T) domain.QuestionRepository {
+	return NewSQLiteQuestionRepository(newSQLiteDB(t))
+}
+
+// newSQLiteDB opens a migrated in-memory database, closed with the test.
+func newSQLiteDB(t *testing.T) *sql.DB {
+	db, err := sql.Open(database.SQLiteDriver, "file::memory:?_foreign_keys=on")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sqlite database", err)
+	}
+	// every new connection would get its own empty in-memory database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := database.NewMigrator(db, database.DriverSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("an error '%s' was not expected when migrating the database", err)
+	}
+	return db
+}
+
+func TestSQLite_StoreAndGetByNumber(t *testing.T) {
+	questionRepo := NewSQLite(t)
+
+	question := *q
+	err := questionRepo.Store(&question)
+	assert.NoError(t, err)
+	assert.NotZero(t, question.ID)
+
+	stored, err := questionRepo.GetByNumber(q.Number)
+	assert.NoError(t, err)
+	assert.Equal(t, question, stored)
+}
+
+func TestSQLite_GetByNumber_FailRecordNotFound(t *testing.T) {
+	questionRepo := NewSQLite(t)
+
+	question, err := questionRepo.GetByNumber("99")
+	assert.Empty(t, question)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestSQLite_GetAll(t *testing.T) {
+	questionRepo := NewSQLite(t)
+
+	assert.NoError(t, questionRepo.Store(&domain.Question{Number: "1", Question: "lorem ipsum?", Answer: "1", AnswerType: domain.AnswerTypeNumeric}))
+	assert.NoError(t, questionRepo.Store(&domain.Question{Number: "2", Question: "dolor sit amet?", Answer: "Paris", AnswerType: domain.AnswerTypeText}))
+
+	questions, err := questionRepo.GetAll(domain.QuestionListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, questions, 2)
+	assert.Equal(t, "1", questions[0].Number)
+}
+
+func TestSQLite_SoftDeleteRestoreAndPurge(t *testing.T) {
+	questionRepo := NewSQLite(t)
+
+	assert.NoError(t, questionRepo.Store(&domain.Question{Number: "1", Question: "lorem ipsum?", Answer: "1", AnswerType: domain.AnswerTypeNumeric}))
+	assert.NoError(t, questionRepo.Destroy(q.Number))
+
+	_, err := questionRepo.GetByNumber(q.Number)
+	assert.Error(t, err)
+	assert.Error(t, questionRepo.Destroy(q.Number))
+
+	questions, err := questionRepo.GetAll(domain.QuestionListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, questions)
+
+	trashed, err := questionRepo.GetTrashed()
+	assert.NoError(t, err)
+	assert.Len(t, trashed, 1)
+	assert.NotNil(t, trashed[0].DeletedAt)
+
+	assert.NoError(t, questionRepo.Restore(q.Number))
+	assert.Error(t, questionRepo.Restore(q.Number))
+	_, err = questionRepo.GetByNumber(q.Number)
+	assert.NoError(t, err)
+
+	assert.NoError(t, questionRepo.Destroy(q.Number))
+	purged, err := questionRepo.Purge(time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
+
+	purged, err = questionRepo.Purge(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	_, err = questionRepo.GetTrashedByNumber(q.Number)
+	assert.Error(t, err)
+}
+
+func TestSQLite_Store_FailUniqueViolation(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE questions (id INTEGER PRIMARY KEY AUTOINCREMENT, number VARCHAR(100) UNIQUE, question VARCHAR(100), answer VARCHAR(100), answer_type VARCHAR(20), lang VARCHAR(5), grading_rule VARCHAR(10), grading_tolerance VARCHAR(50), grading_min VARCHAR(50), grading_max VARCHAR(50), fuzzy_threshold INTEGER, time_limit_ms INTEGER, explanation VARCHAR(1000), deleted_at DATETIME)")
+	assert.NoError(t, err)
+
+	questionRepo := &questionRepository{sqlRepository{db, sqliteDialect{}}}
+	assert.NoError(t, questionRepo.Store(&domain.Question{Number: "1", Question: "lorem?", Answer: "1", AnswerType: domain.AnswerTypeNumeric}))
+
+	err = questionRepo.Store(&domain.Question{Number: "1", Question: "ipsum?", Answer: "2", AnswerType: domain.AnswerTypeNumeric})
+	assert.Error(t, err)
+	assert.Equal(t, "Question no 1 already existed!", err.Error())
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+}
+
+// newSQLiteFileDB opens a migrated database in a file of the test, which
+// unlike the in-memory one takes several connections at once.
+func newSQLiteFileDB(t *testing.T) *sql.DB {
+	path := filepath.Join(t.TempDir(), "quiz_master.db")
+	db, err := sql.Open(database.SQLiteDriver, "file:"+path+"?_foreign_keys=on&_busy_timeout=5000&_txlock=immediate")
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a sqlite database", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := database.NewMigrator(db, database.DriverSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("an error '%s' was not expected when migrating the database", err)
+	}
+	return db
+}
+
+func TestSQLite_Store_Concurrent(t *testing.T) {
+	db := newSQLiteFileDB(t)
+	questionRepo := NewSQLiteQuestionRepository(db)
+
+	const writers = 32
+	errs := make(chan error, writers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs <- questionRepo.Store(&domain.Question{Number: "1", Question: fmt.Sprintf("lorem %d?", i), Answer: "1", AnswerType: domain.AnswerTypeNumeric,
+				Choices: []domain.Choice{{Label: "A", Text: "1", Correct: true}}})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	stored := 0
+	for err := range errs {
+		if err == nil {
+			stored++
+			continue
+		}
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+	}
+	assert.Equal(t, 1, stored)
+
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM questions WHERE number = '1'").Scan(&count))
+	assert.Equal(t, 1, count)
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM question_choices").Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestSQLite_Upsert_Concurrent(t *testing.T) {
+	db := newSQLiteFileDB(t)
+	questionRepo := NewSQLiteQuestionRepository(db)
+
+	const writers = 32
+	created := make(chan bool, writers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			ok, err := questionRepo.Upsert(&domain.Question{Number: "1", Question: fmt.Sprintf("lorem %d?", i), Answer: "1", AnswerType: domain.AnswerTypeNumeric,
+				Hints: []string{"one"}})
+			assert.NoError(t, err)
+			created <- ok
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	close(created)
+
+	inserts := 0
+	for ok := range created {
+		if ok {
+			inserts++
+		}
+	}
+	assert.Equal(t, 1, inserts)
+
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM questions").Scan(&count))
+	assert.Equal(t, 1, count)
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM question_hints").Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestSQLite_Upsert(t *testing.T) {
+	questionRepo := NewSQLite(t)
+
+	question := domain.Question{Number: "1", Question: "lorem ipsum?", Answer: "1", AnswerType: domain.AnswerTypeNumeric}
+	created, err := questionRepo.Upsert(&question)
+	assert.NoError(t, err)
+	assert.True(t, created)
+
+	replacement := domain.Question{Number: "1", Question: "dolor sit amet?", Answer: "2", AnswerType: domain.AnswerTypeNumeric, Hints: []string{"two"}}
+	created, err = questionRepo.Upsert(&replacement)
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, question.ID, replacement.ID)
+
+	stored, err := questionRepo.GetByNumber("1")
+	assert.NoError(t, err)
+	assert.Equal(t, "dolor sit amet?", stored.Question)
+	assert.Equal(t, []string{"two"}, stored.Hints)
+
+	assert.NoError(t, questionRepo.Destroy("1"))
+	_, err = questionRepo.Upsert(&domain.Question{Number: "1", Question: "lorem?", Answer: "1", AnswerType: domain.AnswerTypeNumeric})
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+}
+
+func TestSQLite_ListSortedNumerically(t *testing.T) {
+	questionRepo := NewSQLite(t)
+
+	for _, n := range []string{"10", "2", "1.5", "33"} {
+		assert.NoError(t, questionRepo.Store(&domain.Question{Number: n, Question: "Question " + n + "?", Answer: n, AnswerType: domain.AnswerTypeNumeric}))
+	}
+
+	questions, err := questionRepo.GetAll(domain.QuestionListOptions{Limit: 2, Offset: 1})
+	assert.NoError(t, err)
+	assert.Len(t, questions, 2)
+	assert.Equal(t, "2", questions[0].Number)
+	assert.Equal(t, "10", questions[1].Number)
+
+	questions, err = questionRepo.GetAll(domain.QuestionListOptions{Sort: domain.SortCreated, Desc: true, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "33", questions[0].Number)
+
+	total, err := questionRepo.Count(domain.QuestionFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, total)
+}
+
+func TestSQLite_StoreAndUpdateChoices(t *testing.T) {
+	questionRepo := NewSQLite(t)
+
+	question := domain.Question{Number: "1", Question: "Capital of France?", Answer: "A", AnswerType: domain.AnswerTypeChoice, Choices: []domain.Choice{
+		{Label: "B", Text: "Rome"},
+		{Label: "A", Text: "Paris", Correct: true},
+	}}
+	assert.NoError(t, questionRepo.Store(&question))
+	assert.NoError(t, questionRepo.Store(&domain.Question{Number: "2", Question: "lorem ipsum?", Answer: "1", AnswerType: domain.AnswerTypeNumeric}))
+
+	stored, err := questionRepo.GetByNumber("1")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Choice{{Label: "A", Text: "Paris", Correct: true}, {Label: "B", Text: "Rome"}}, stored.Choices)
+
+	stored.Answer = "B"
+	stored.Choices = []domain.Choice{{Label: "A", Text: "Paris"}, {Label: "B", Text: "Lyon", Correct: true}}
+	assert.NoError(t, questionRepo.Update(&stored))
+
+	questions, err := questionRepo.GetAll(domain.QuestionListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, questions, 2)
+	assert.Equal(t, stored.Choices, questions[0].Choices)
+	assert.Empty(t, questions[1].Choices)
+}
+
+func TestSQLite_StoreAndUpdateHints(t *testing.T) {
+	questionRepo := NewSQLite(t)
+
+	question := domain.Question{Number: "1", Question: "Capital of France?", Answer: "Paris", AnswerType: domain.AnswerTypeText,
+		Hints: []string{"It's in Europe", "It starts with P"}, Explanation: "Paris has been the capital since 987."}
+	assert.NoError(t, questionRepo.Store(&question))
+
+	stored, err := questionRepo.GetByNumber("1")
+	assert.NoError(t, err)
+	assert.Equal(t, question.Hints, stored.Hints)
+	assert.Equal(t, question.Explanation, stored.Explanation)
+
+	stored.Hints = []string{"It's on the Seine"}
+	stored.Explanation = ""
+	assert.NoError(t, questionRepo.Update(&stored))
+
+	questions, err := questionRepo.GetAll(domain.QuestionListOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"It's on the Seine"}, questions[0].Hints)
+	assert.Empty(t, questions[0].Explanation)
+}
+
+func TestSQLite_Tags(t *testing.T) {
+	questionRepo := NewSQLite(t)
+
+	for _, n := range []string{"1", "2", "3"} {
+		assert.NoError(t, questionRepo.Store(&domain.Question{Number: n, Question: "lorem ipsum?", Answer: n, AnswerType: domain.AnswerTypeNumeric}))
+	}
+	questions, err := questionRepo.GetAll(domain.QuestionListOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, questionRepo.AddTags(questions[0].ID, []string{"math", "easy"}))
+	assert.NoError(t, questionRepo.AddTags(questions[1].ID, []string{"math"}))
+	assert.NoError(t, questionRepo.AddTags(questions[2].ID, []string{"history"}))
+
+	math, err := questionRepo.GetAll(domain.QuestionListOptions{Filter: domain.QuestionFilter{Tags: []string{"math"}}})
+	assert.NoError(t, err)
+	assert.Len(t, math, 2)
+	assert.Equal(t, []string{"easy", "math"}, math[0].Tags)
+
+	either, err := questionRepo.GetAll(domain.QuestionListOptions{Filter: domain.QuestionFilter{Tags: []string{"easy", "history"}}})
+	assert.NoError(t, err)
+	assert.Len(t, either, 2)
+	assert.Equal(t, "1", either[0].Number)
+	assert.Equal(t, "3", either[1].Number)
+
+	assert.NoError(t, questionRepo.Destroy("2"))
+	assert.NoError(t, questionRepo.RemoveTags(questions[0].ID, []string{"easy"}))
+	counts, err := questionRepo.CountTags()
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.TagCount{{Tag: "history", Questions: 1}, {Tag: "math", Questions: 1}}, counts)
+}
+
+func TestSQLite_Search(t *testing.T) {
+	questionRepo := NewSQLite(t)
+
+	assert.NoError(t, questionRepo.Store(&domain.Question{Number: "1", Question: "Capital of France?", Answer: "Paris", AnswerType: domain.AnswerTypeText, Explanation: "Paris has been the capital of France since 987, Paris being the seat of the Capetians."}))
+	assert.NoError(t, questionRepo.Store(&domain.Question{Number: "2", Question: "Capital of Italy?", Answer: "Rome", AnswerType: domain.AnswerTypeText}))
+	assert.NoError(t, questionRepo.Store(&domain.Question{Number: "3", Question: "River through Paris?", Answer: "Seine", AnswerType: domain.AnswerTypeText,
+		Explanation: "The Seine runs 777 kilometres from Burgundy to the English Channel at Le Havre."}))
+
+	results, err := questionRepo.Search(domain.SearchQuery{Terms: "paris"})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "1", results[0].Question.Number)
+	assert.Equal(t, "3", results[1].Question.Number)
+	assert.Greater(t, results[0].Score, results[1].Score)
+	assert.Equal(t, "River through **Paris**?", results[1].Snippet)
+
+	results, err = questionRepo.Search(domain.SearchQuery{Terms: "capital rome"})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "2", results[0].Question.Number)
+
+	results, err = questionRepo.Search(domain.SearchQuery{Terms: `"paris`, Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	assert.NoError(t, questionRepo.Destroy("3"))
+	results, err = questionRepo.Search(domain.SearchQuery{Terms: "seine"})
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	results, err = questionRepo.Search(domain.SearchQuery{Terms: "seine", IncludeDeleted: true})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.NotNil(t, results[0].Question.DeletedAt)
+
+	question, err := questionRepo.GetByNumber("2")
+	assert.NoError(t, err)
+	question.Answer = "Roma"
+	assert.NoError(t, questionRepo.Update(&question))
+	results, err = questionRepo.Search(domain.SearchQuery{Terms: "rome"})
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestSQLite_PurgeDeletesChoices(t *testing.T) {
+	db, err := sql.Open(database.SQLiteDriver, "file::memory:?_foreign_keys=on")
+	assert.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db, database.DriverSQLite)
+	assert.NoError(t, err)
+	_, err = migrator.Up()
+	assert.NoError(t, err)
+
+	questionRepo := NewSQLiteQuestionRepository(db)
+	assert.NoError(t, questionRepo.Store(&domain.Question{Number: "1", Question: "Capital of France?", Answer: "A", AnswerType: domain.AnswerTypeChoice, Choices: []domain.Choice{
+		{Label: "A", Text: "Paris", Correct: true},
+		{Label: "B", Text: "Rome"},
+	}}))
+	assert.NoError(t, questionRepo.Destroy("1"))
+	_, err = questionRepo.Purge(time.Now())
+	assert.NoError(t, err)
+
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM question_choices").Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func TestSQLite_Aliases(t *testing.T) {
+	questionRepo := NewSQLite(t)
+
+	question := domain.Question{Number: "1", Question: "Largest country of North America?", Answer: "Canada", AnswerType: domain.AnswerTypeText, Fuzzy: 1}
+	assert.NoError(t, questionRepo.Store(&question))
+	assert.NoError(t, questionRepo.AddAlias(question.ID, "CA"))
+	assert.NoError(t, questionRepo.AddAlias(question.ID, "Kanada"))
+	assert.Error(t, questionRepo.AddAlias(question.ID, "CA"))
+
+	stored, err := questionRepo.GetByNumber("1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CA", "Kanada"}, stored.Aliases)
+	assert.Equal(t, 1, stored.Fuzzy)
+
+	assert.NoError(t, questionRepo.RemoveAlias(question.ID, "CA"))
+	assert.Error(t, questionRepo.RemoveAlias(question.ID, "CA"))
+
+	questions, err := questionRepo.GetAll(domain.QuestionListOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Kanada"}, questions[0].Aliases)
+}
+
+func TestSQLite_Grading(t *testing.T) {
+	questionRepo := NewSQLite(t)
+
+	question := domain.Question{Number: "1", Question: "Value of pi?", Answer: "3.14", AnswerType: domain.AnswerTypeNumeric,
+		Grading: domain.Grading{Rule: domain.GradingAbsolute, Tolerance: "0.01"}}
+	assert.NoError(t, questionRepo.Store(&question))
+
+	question.Grading = domain.Grading{Rule: domain.GradingRange, Min: "3", Max: "3.2"}
+	assert.NoError(t, questionRepo.Update(&question))
+
+	stored, err := questionRepo.GetByNumber("1")
+	assert.NoError(t, err)
+	assert.Equal(t, question.Grading, stored.Grading)
+}
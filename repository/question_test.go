@@ -7,16 +7,19 @@ import (
 	"quiz_master/domain"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 
 var q = &domain.Question{
-	ID:       1,
-	Number:   "1",
-	Question: "lorem ipsum dolor sit amet?",
-	Answer:   "1",
+	ID:         1,
+	Number:     "1",
+	Question:   "lorem ipsum dolor sit amet?",
+	Answer:     "1",
+	AnswerType: domain.AnswerTypeNumeric,
 }
 
 func NewMock() (*sql.DB, sqlmock.Sqlmock) {
@@ -32,27 +35,37 @@ func TestGetAll_Success(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := "SELECT number,question,answer FROM questions WHERE deleted_at IS NULL ORDER BY number ASC"
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation FROM questions WHERE deleted_at IS NULL ORDER BY CAST(number AS DECIMAL(65,10)) ASC, id ASC")
 
-	rows := sqlmock.NewRows([]string{"number", "question", "answer"}).
-		AddRow(q.Number, q.Question, q.Answer)
+	rows := sqlmock.NewRows([]string{"id", "number", "question", "answer", "answer_type", "lang", "grading_rule", "grading_tolerance", "grading_min", "grading_max", "fuzzy_threshold", "time_limit_ms", "explanation"}).
+		AddRow(q.ID, q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation)
 	mock.ExpectQuery(query).WillReturnRows(rows)
-
-	questions, err := questionRepo.GetAll()
+	aliases := sqlmock.NewRows([]string{"question_id", "alias"}).
+		AddRow(q.ID, "one").
+		AddRow(q.ID, "uno")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT question_id,alias FROM question_aliases WHERE question_id IN (?) ORDER BY question_id, id")).
+		WillReturnRows(aliases)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT question_id,content FROM question_hints WHERE question_id IN (?) ORDER BY question_id, position")).
+		WillReturnRows(sqlmock.NewRows([]string{"question_id", "content"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT question_tags.question_id,tags.name FROM question_tags JOIN tags ON tags.id = question_tags.tag_id WHERE question_tags.question_id IN (?) ORDER BY question_tags.question_id, tags.name")).
+		WillReturnRows(sqlmock.NewRows([]string{"question_id", "name"}))
+
+	questions, err := questionRepo.GetAll(domain.QuestionListOptions{})
 	assert.NotEmpty(t, questions)
 	assert.NoError(t, err)
 	assert.Len(t, questions, 1)
+	assert.Equal(t, []string{"one", "uno"}, questions[0].Aliases)
 }
 
 func TestGetAll_FailBecauseErrorQuery(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := "SELECT number,question,answer FROM questions WHERE deleted_at IS NULL ORDER BY number ASC"
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation FROM questions WHERE deleted_at IS NULL ORDER BY CAST(number AS DECIMAL(65,10)) ASC, id ASC")
 
 	mock.ExpectQuery(query).WillReturnError(fmt.Errorf("some error"))
 
-	questions, err := questionRepo.GetAll()
+	questions, err := questionRepo.GetAll(domain.QuestionListOptions{})
 	assert.Empty(t, questions)
 	assert.Error(t, err)
 }
@@ -61,13 +74,13 @@ func TestGetAll_FailBecauseDataTypeIsWrong(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := "SELECT number,question,answer FROM questions WHERE deleted_at IS NULL ORDER BY number ASC"
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation FROM questions WHERE deleted_at IS NULL ORDER BY CAST(number AS DECIMAL(65,10)) ASC, id ASC")
 
-	rows := sqlmock.NewRows([]string{"number", "question", "answer"}).
-		AddRow(q.Number, q.Question, nil)
+	rows := sqlmock.NewRows([]string{"id", "number", "question", "answer", "answer_type", "lang", "grading_rule", "grading_tolerance", "grading_min", "grading_max", "fuzzy_threshold", "time_limit_ms", "explanation"}).
+		AddRow(q.ID, q.Number, q.Question, nil, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation)
 	mock.ExpectQuery(query).WillReturnRows(rows)
 
-	questions, err := questionRepo.GetAll()
+	questions, err := questionRepo.GetAll(domain.QuestionListOptions{})
 	assert.Empty(t, questions)
 	assert.Error(t, err)
 }
@@ -76,11 +89,13 @@ func TestStore_Success(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := regexp.QuoteMeta("INSERT INTO questions(number, question, answer) VALUES(?, ?, ?)")
+	mock.ExpectBegin()
+	query := regexp.QuoteMeta("INSERT INTO questions(number, question, answer, answer_type, lang, grading_rule, grading_tolerance, grading_min, grading_max, fuzzy_threshold, time_limit_ms, explanation) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().
-		WithArgs(q.Number, q.Question, q.Answer).
+		WithArgs(q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	err := questionRepo.Store(q)
 	assert.NoError(t, err)
@@ -90,10 +105,11 @@ func TestStore_FailQueryNotMatch(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := regexp.QuoteMeta("INSERT INTO questions(id,number, question, answer) VALUES(?, ?, ?)")
+	mock.ExpectBegin()
+	query := regexp.QuoteMeta("INSERT INTO questions(id,number, question, answer, answer_type, lang, grading_rule, grading_tolerance, grading_min, grading_max, fuzzy_threshold, time_limit_ms, explanation) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().
-		WithArgs(q.Number, q.Question, q.Answer).
+		WithArgs(q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	err := questionRepo.Store(q)
@@ -104,41 +120,70 @@ func TestStore_FailErrorOnQuery(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := regexp.QuoteMeta("INSERT INTO questions(number, question, answer) VALUES(?, ?, ?)")
+	mock.ExpectBegin()
+	query := regexp.QuoteMeta("INSERT INTO questions(number, question, answer, answer_type, lang, grading_rule, grading_tolerance, grading_min, grading_max, fuzzy_threshold, time_limit_ms, explanation) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().
-		WithArgs(q.Number, q.Question, q.Answer).
+		WithArgs(q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation).
 		WillReturnError(fmt.Errorf("some error"))
+	mock.ExpectRollback()
 
 	err := questionRepo.Store(q)
 	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrStorage)
 }
 
 func TestStore_FailNoRowAffected(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := regexp.QuoteMeta("INSERT INTO questions(number, question, answer) VALUES(?, ?, ?)")
+	mock.ExpectBegin()
+	query := regexp.QuoteMeta("INSERT INTO questions(number, question, answer, answer_type, lang, grading_rule, grading_tolerance, grading_min, grading_max, fuzzy_threshold, time_limit_ms, explanation) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	prep := mock.ExpectPrepare(query)
 
 	prep.ExpectExec().
-		WithArgs(q.Number, q.Question, q.Answer).
+		WithArgs(q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation).
 		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
 
 	err := questionRepo.Store(q)
 	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrStorage)
+}
+
+func TestStore_FailDuplicateKey(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	mock.ExpectBegin()
+	query := regexp.QuoteMeta("INSERT INTO questions(number, question, answer, answer_type, lang, grading_rule, grading_tolerance, grading_min, grading_max, fuzzy_threshold, time_limit_ms, explanation) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'number'"})
+	mock.ExpectRollback()
+
+	err := questionRepo.Store(q)
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+	assert.Equal(t, "Question no 1 already existed!", err.Error())
 }
 
 func TestGetByNumber_Success(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := regexp.QuoteMeta("SELECT id,number,question,answer FROM questions WHERE number = ?")
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation FROM questions WHERE number = ? AND deleted_at IS NULL")
 	prep := mock.ExpectPrepare(query)
 
-	rows := sqlmock.NewRows([]string{"id", "number", "question", "answer"}).
-		AddRow(q.ID, q.Number, q.Question, q.Answer)
+	rows := sqlmock.NewRows([]string{"id", "number", "question", "answer", "answer_type", "lang", "grading_rule", "grading_tolerance", "grading_min", "grading_max", "fuzzy_threshold", "time_limit_ms", "explanation"}).
+		AddRow(q.ID, q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation)
 	prep.ExpectQuery().WithArgs(q.Number).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT question_id,alias FROM question_aliases WHERE question_id IN (?) ORDER BY question_id, id")).
+		WillReturnRows(sqlmock.NewRows([]string{"question_id", "alias"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT question_id,content FROM question_hints WHERE question_id IN (?) ORDER BY question_id, position")).
+		WillReturnRows(sqlmock.NewRows([]string{"question_id", "content"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT question_tags.question_id,tags.name FROM question_tags JOIN tags ON tags.id = question_tags.tag_id WHERE question_tags.question_id IN (?) ORDER BY question_tags.question_id, tags.name")).
+		WillReturnRows(sqlmock.NewRows([]string{"question_id", "name"}))
 
 	question, err := questionRepo.GetByNumber(q.Number)
 	assert.NotEmpty(t, question)
@@ -152,8 +197,8 @@ func TestGetByNumber_FailQueryNotMatch(t *testing.T) {
 	query := regexp.QuoteMeta("SELECT id,number,question FROM questions WHERE number = ?")
 	prep := mock.ExpectPrepare(query)
 
-	rows := sqlmock.NewRows([]string{"id", "number", "question", "answer"}).
-		AddRow(q.ID, q.Number, q.Question, q.Answer)
+	rows := sqlmock.NewRows([]string{"id", "number", "question", "answer", "answer_type", "lang", "grading_rule", "grading_tolerance", "grading_min", "grading_max", "fuzzy_threshold", "time_limit_ms", "explanation"}).
+		AddRow(q.ID, q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation)
 	prep.ExpectQuery().WithArgs(q.Number).WillReturnRows(rows)
 
 	question, err := questionRepo.GetByNumber(q.Number)
@@ -165,7 +210,7 @@ func TestGetByNumber_FailErrorQuery(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := regexp.QuoteMeta("SELECT id,number,question,answer FROM questions WHERE number = ?")
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation FROM questions WHERE number = ? AND deleted_at IS NULL")
 	prep := mock.ExpectPrepare(query)
 
 	prep.ExpectQuery().WithArgs(q.Number).WillReturnError(fmt.Errorf("some error"))
@@ -179,7 +224,7 @@ func TestGetByNumber_FailRecordNotFound(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := regexp.QuoteMeta("SELECT id,number,question,answer FROM questions WHERE number = ?")
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation FROM questions WHERE number = ? AND deleted_at IS NULL")
 	prep := mock.ExpectPrepare(query)
 
 	prep.ExpectQuery().WithArgs(q.Number).WillReturnError(sql.ErrNoRows)
@@ -189,14 +234,27 @@ func TestGetByNumber_FailRecordNotFound(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGetByNumber_FailDatabaseUnavailable(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation FROM questions WHERE number = ? AND deleted_at IS NULL")
+	mock.ExpectPrepare(query).WillReturnError(&mysql.MySQLError{Number: 1045, Message: "Access denied for user 'quiz'"})
+
+	_, err := questionRepo.GetByNumber(q.Number)
+	assert.ErrorIs(t, err, domain.ErrUnavailable)
+	var mysqlErr *mysql.MySQLError
+	assert.ErrorAs(t, err, &mysqlErr)
+}
+
 func TestDestroy_Success(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := regexp.QuoteMeta("DELETE FROM questions WHERE number = ? AND deleted_at IS NULL")
+	query := regexp.QuoteMeta("UPDATE questions SET deleted_at = ? WHERE number = ? AND deleted_at IS NULL")
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().
-		WithArgs(q.Number).
+		WithArgs(sqlmock.AnyArg(), q.Number).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	err := questionRepo.Destroy(q.Number)
@@ -207,10 +265,10 @@ func TestDestroy_FailQueryNotMatch(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := regexp.QuoteMeta("DELETE FROM questions WHERE id = ? AND deleted_at IS NULL")
+	query := regexp.QuoteMeta("UPDATE questions SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL")
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().
-		WithArgs(q.Number).
+		WithArgs(sqlmock.AnyArg(), q.Number).
 		WillReturnResult(sqlmock.NewResult(0, 0))
 
 	err := questionRepo.Destroy(q.Number)
@@ -221,26 +279,289 @@ func TestDestroy_FailErrorQuery(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := regexp.QuoteMeta("DELETE FROM questions WHERE number = ? AND deleted_at IS NULL")
+	query := regexp.QuoteMeta("UPDATE questions SET deleted_at = ? WHERE number = ? AND deleted_at IS NULL")
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().
-		WithArgs(q.Number).
+		WithArgs(sqlmock.AnyArg(), q.Number).
 		WillReturnError(fmt.Errorf("some error"))
 
 	err := questionRepo.Destroy(q.Number)
 	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrStorage)
 }
 
 func TestDestroy_FailNoRowAffected(t *testing.T) {
 	db, mock := NewMock()
 	questionRepo := NewQuestionRepository(db)
 
-	query := regexp.QuoteMeta("DELETE FROM questions WHERE number = ? AND deleted_at IS NULL")
+	query := regexp.QuoteMeta("UPDATE questions SET deleted_at = ? WHERE number = ? AND deleted_at IS NULL")
 	prep := mock.ExpectPrepare(query)
 	prep.ExpectExec().
-		WithArgs(q.Number).
+		WithArgs(sqlmock.AnyArg(), q.Number).
 		WillReturnResult(sqlmock.NewResult(0, 0))
 
 	err := questionRepo.Destroy(q.Number)
 	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Equal(t, "Question not found", err.Error())
+}
+
+func TestGetTrashed_Success(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation,deleted_at FROM questions WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC")
+
+	deletedAt := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "number", "question", "answer", "answer_type", "lang", "grading_rule", "grading_tolerance", "grading_min", "grading_max", "fuzzy_threshold", "time_limit_ms", "explanation", "deleted_at"}).
+		AddRow(q.ID, q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation, deletedAt)
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	questions, err := questionRepo.GetTrashed()
+	assert.NoError(t, err)
+	assert.Len(t, questions, 1)
+	assert.Equal(t, deletedAt, *questions[0].DeletedAt)
+}
+
+func TestGetTrashed_FailRowError(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation,deleted_at FROM questions WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC")
+
+	deletedAt := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "number", "question", "answer", "answer_type", "lang", "grading_rule", "grading_tolerance", "grading_min", "grading_max", "fuzzy_threshold", "time_limit_ms", "explanation", "deleted_at"}).
+		AddRow(q.ID, q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation, deletedAt).
+		AddRow(q.ID+1, "2", q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation, deletedAt).
+		RowError(1, fmt.Errorf("connection lost"))
+	mock.ExpectQuery(query).WillReturnRows(rows)
+
+	questions, err := questionRepo.GetTrashed()
+	assert.Nil(t, questions)
+	assert.ErrorIs(t, err, domain.ErrStorage)
+}
+
+func TestGetTrashedByNumber_FailRecordNotFound(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation,deleted_at FROM questions WHERE number = ? AND deleted_at IS NOT NULL")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectQuery().WithArgs(q.Number).WillReturnError(sql.ErrNoRows)
+
+	question, err := questionRepo.GetTrashedByNumber(q.Number)
+	assert.Empty(t, question)
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestRestore_Success(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	query := regexp.QuoteMeta("UPDATE questions SET deleted_at = NULL WHERE number = ? AND deleted_at IS NOT NULL")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(q.Number).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := questionRepo.Restore(q.Number)
+	assert.NoError(t, err)
+}
+
+func TestRestore_FailNoRowAffected(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	query := regexp.QuoteMeta("UPDATE questions SET deleted_at = NULL WHERE number = ? AND deleted_at IS NOT NULL")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(q.Number).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := questionRepo.Restore(q.Number)
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Equal(t, "Question not found in trash", err.Error())
+}
+
+func TestPurge_Success(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	before := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta("DELETE FROM questions WHERE deleted_at IS NOT NULL AND deleted_at <= ?")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	purged, err := questionRepo.Purge(before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+}
+
+func TestUpdate_Success(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM questions WHERE id = ? AND deleted_at IS NULL")).
+		WithArgs(q.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(q.ID))
+	query := regexp.QuoteMeta("UPDATE questions SET number = ?, question = ?, answer = ?, answer_type = ?, lang = ?, grading_rule = ?, grading_tolerance = ?, grading_min = ?, grading_max = ?, fuzzy_threshold = ?, time_limit_ms = ?, explanation = ? WHERE id = ? AND deleted_at IS NULL")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs("2", q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation, q.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	prep = mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM question_choices WHERE question_id = ?"))
+	prep.ExpectExec().
+		WithArgs(q.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	prep = mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM question_hints WHERE question_id = ?"))
+	prep.ExpectExec().
+		WithArgs(q.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := questionRepo.Update(&domain.Question{ID: q.ID, Number: "2", Question: q.Question, Answer: q.Answer, AnswerType: q.AnswerType})
+	assert.NoError(t, err)
+}
+
+func TestUpdate_SuccessOnlyHints(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM questions WHERE id = ? AND deleted_at IS NULL")).
+		WithArgs(q.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(q.ID))
+	query := regexp.QuoteMeta("UPDATE questions SET number = ?, question = ?, answer = ?, answer_type = ?, lang = ?, grading_rule = ?, grading_tolerance = ?, grading_min = ?, grading_max = ?, fuzzy_threshold = ?, time_limit_ms = ?, explanation = ? WHERE id = ? AND deleted_at IS NULL")
+	prep := mock.ExpectPrepare(query)
+	// MySQL reports no row affected when the row is left as it was
+	prep.ExpectExec().
+		WithArgs(q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation, q.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	prep = mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM question_choices WHERE question_id = ?"))
+	prep.ExpectExec().
+		WithArgs(q.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	prep = mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM question_hints WHERE question_id = ?"))
+	prep.ExpectExec().
+		WithArgs(q.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	prep = mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO question_hints(question_id, position, content) VALUES(?, ?, ?)"))
+	prep.ExpectExec().
+		WithArgs(q.ID, 1, "It's even").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := questionRepo.Update(&domain.Question{ID: q.ID, Number: q.Number, Question: q.Question, Answer: q.Answer, AnswerType: q.AnswerType, Hints: []string{"It's even"}})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdate_FailNotFound(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM questions WHERE id = ? AND deleted_at IS NULL")).
+		WithArgs(q.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	err := questionRepo.Update(&domain.Question{ID: q.ID, Number: q.Number, Question: q.Question, Answer: q.Answer, AnswerType: q.AnswerType})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Equal(t, "Question not found", err.Error())
+}
+
+func TestAddAlias_Success(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	query := regexp.QuoteMeta("INSERT INTO question_aliases(question_id, alias) VALUES(?, ?)")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(1, "one").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := questionRepo.AddAlias(1, "one")
+	assert.NoError(t, err)
+}
+
+func TestRemoveAlias_FailNoRowAffected(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	query := regexp.QuoteMeta("DELETE FROM question_aliases WHERE question_id = ? AND alias = ?")
+	prep := mock.ExpectPrepare(query)
+	prep.ExpectExec().
+		WithArgs(1, "one").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := questionRepo.RemoveAlias(1, "one")
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestGetAll_FilterByTags(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation FROM questions WHERE deleted_at IS NULL AND id IN (SELECT question_tags.question_id FROM question_tags JOIN tags ON tags.id = question_tags.tag_id WHERE tags.name IN (?,?)) ORDER BY CAST(number AS DECIMAL(65,10)) ASC, id ASC")
+	mock.ExpectQuery(query).WithArgs("geography", "history").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "number", "question", "answer", "answer_type", "lang", "grading_rule", "grading_tolerance", "grading_min", "grading_max", "fuzzy_threshold", "time_limit_ms", "explanation"}))
+
+	questions, err := questionRepo.GetAll(domain.QuestionListOptions{Filter: domain.QuestionFilter{Tags: []string{"geography", "history"}}})
+	assert.NoError(t, err)
+	assert.Empty(t, questions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearch_Success(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation,deleted_at,hits.score,hits.snippet FROM questions JOIN (SELECT id AS question_id, MATCH(question, answer, explanation) AGAINST (? IN BOOLEAN MODE) AS score, CONCAT_WS(' | ', question, answer, NULLIF(explanation, '')) AS snippet FROM questions WHERE MATCH(question, answer, explanation) AGAINST (? IN BOOLEAN MODE)) hits ON hits.question_id = questions.id WHERE deleted_at IS NULL ORDER BY hits.score DESC, CAST(number AS DECIMAL(65,10)) ASC LIMIT ?")
+	rows := sqlmock.NewRows([]string{"id", "number", "question", "answer", "answer_type", "lang", "grading_rule", "grading_tolerance", "grading_min", "grading_max", "fuzzy_threshold", "time_limit_ms", "explanation", "deleted_at", "score", "snippet"}).
+		AddRow(q.ID, q.Number, q.Question, q.Answer, q.AnswerType, q.Lang, q.Grading.Rule, q.Grading.Tolerance, q.Grading.Min, q.Grading.Max, q.Fuzzy, q.TimeLimit.Milliseconds(), q.Explanation, nil, 1.5, "lorem ipsum dolor sit amet? | 1")
+	mock.ExpectQuery(query).WithArgs(`+"lorem" +"dolor"`, `+"lorem" +"dolor"`, 5).WillReturnRows(rows)
+
+	results, err := questionRepo.Search(domain.SearchQuery{Terms: "lorem dolor", Limit: 5})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, q.Number, results[0].Question.Number)
+	assert.Nil(t, results[0].Question.DeletedAt)
+	assert.Equal(t, 1.5, results[0].Score)
+	assert.Equal(t, "**lorem** ipsum **dolor** sit amet? | 1", results[0].Snippet)
+}
+
+func TestMySQLHighlight(t *testing.T) {
+	snippet := mysqlDialect{}.highlight("Which city has been the capital of France since 987? | Paris | The Capetians made Paris their seat and it stayed the capital ever since.", []string{"paris"})
+	assert.Equal(t, "…of France since 987? | **Paris** | The Capetians made **Paris** their seat and it…", snippet)
+}
+
+func TestGetAll_SortedAndPaged(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	query := regexp.QuoteMeta("SELECT id,number,question,answer,answer_type,lang,grading_rule,grading_tolerance,grading_min,grading_max,fuzzy_threshold,time_limit_ms,explanation FROM questions WHERE deleted_at IS NULL ORDER BY question DESC, CAST(number AS DECIMAL(65,10)) DESC, id DESC LIMIT ? OFFSET ?")
+	mock.ExpectQuery(query).WithArgs(20, 40).WillReturnError(fmt.Errorf("some error"))
+
+	questions, err := questionRepo.GetAll(domain.QuestionListOptions{Sort: domain.SortQuestion, Desc: true, Limit: 20, Offset: 40})
+	assert.Empty(t, questions)
+	assert.Error(t, err)
+}
+
+func TestCount_FilterByTags(t *testing.T) {
+	db, mock := NewMock()
+	questionRepo := NewQuestionRepository(db)
+
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM questions WHERE deleted_at IS NULL AND id IN (SELECT question_tags.question_id FROM question_tags JOIN tags ON tags.id = question_tags.tag_id WHERE tags.name IN (?))")
+	mock.ExpectQuery(query).WithArgs("geography").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+	total, err := questionRepo.Count(domain.QuestionFilter{Tags: []string{"geography"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 12, total)
 }
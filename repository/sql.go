@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"quiz_master/domain"
+)
+
+// errNoRow is what execOne fails with when its statement matched no row,
+// the callers telling what was not found.
+var errNoRow = domain.Errorf(domain.ErrNotFound, "no row matched")
+
+// preparer is satisfied by both *sql.DB and *sql.Tx.
+type preparer interface {
+	Prepare(query string) (*sql.Stmt, error)
+}
+
+// sqlRepository runs queries written once with ? placeholders on the
+// backend of dialect, the repositories embed it.
+type sqlRepository struct {
+	conn    *sql.DB
+	dialect dialect
+}
+
+// transaction runs fn in a transaction, rolling it back if fn fails.
+func (r *sqlRepository) transaction(fn func(tx *sql.Tx) error) error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return r.fail(err)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return r.fail(err)
+	}
+	return r.fail(tx.Commit())
+}
+
+// exec runs a statement and returns the number of rows it changed.
+func (r *sqlRepository) exec(db preparer, query string, args ...interface{}) (int64, error) {
+	stmt, err := db.Prepare(r.dialect.rebind(query))
+	if err != nil {
+		return 0, r.fail(err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(args...)
+	if err != nil {
+		return 0, r.fail(err)
+	}
+
+	return res.RowsAffected()
+}
+
+// execOne runs a statement that has to change exactly one row.
+func (r *sqlRepository) execOne(db preparer, query string, args ...interface{}) error {
+	rows, err := r.exec(db, query, args...)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	switch {
+	case rows == 0:
+		return errNoRow
+	case rows > 1:
+		return domain.Errorf(domain.ErrStorage, "%d rows changed instead of 1", rows)
+	}
+
+	return nil
+}
+
+// insert runs an INSERT of a single row and returns its id. Postgres has no
+// LastInsertId, so there the id is read back through RETURNING.
+func (r *sqlRepository) insert(db preparer, query string, args ...interface{}) (int, error) {
+	if r.dialect.returningID() {
+		stmt, err := db.Prepare(r.dialect.rebind(query + " RETURNING id"))
+		if err != nil {
+			return 0, r.fail(err)
+		}
+		defer stmt.Close()
+
+		var id int
+		err = stmt.QueryRow(args...).Scan(&id)
+		return id, r.fail(err)
+	}
+
+	stmt, err := db.Prepare(r.dialect.rebind(query))
+	if err != nil {
+		return 0, r.fail(err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(args...)
+	if err != nil {
+		return 0, r.fail(err)
+	}
+
+	rows, _ := res.RowsAffected()
+	if rows != 1 {
+		return 0, domain.Errorf(domain.ErrStorage, "%d rows inserted instead of 1", rows)
+	}
+
+	id, _ := res.LastInsertId()
+	return int(id), nil
+}
+
+// fail maps an error of the database driver onto the domain errors: a
+// unique constraint onto ErrAlreadyExists, a database out of reach onto
+// ErrUnavailable and anything else onto ErrStorage. Errors already of the
+// domain are returned as they are.
+func (r *sqlRepository) fail(err error) error {
+	var domainErr *domain.Error
+	var invalid *domain.ValidationError
+	var netErr net.Error
+	switch {
+	case err == nil, errors.As(err, &domainErr), errors.As(err, &invalid):
+		return err
+	case r.dialect.isUniqueViolation(err):
+		return domain.NewError(domain.ErrAlreadyExists, err)
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone), errors.As(err, &netErr), r.dialect.isUnavailable(err):
+		return domain.NewError(domain.ErrUnavailable, err)
+	default:
+		return domain.NewError(domain.ErrStorage, err)
+	}
+}
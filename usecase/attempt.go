@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"math"
+	"quiz_master/domain"
+	"strings"
+	"time"
+
+	helper "quiz_master/helper"
+)
+
+type attemptUsecase struct {
+	attemptRepository domain.AttemptRepository
+	hintPenalty       float64
+}
+
+type AttemptOption func(*attemptUsecase)
+
+func NewAttemptUsecase(repo domain.AttemptRepository, options ...AttemptOption) domain.AttemptUsecase {
+	u := &attemptUsecase{repo, 0}
+	for _, o := range options {
+		o(u)
+	}
+	return u
+}
+
+// WithHintPenalty sets how much each hint used takes off the score of the
+// answer that follows it, which never drops below 0.
+func WithHintPenalty(penalty float64) AttemptOption {
+	return func(u *attemptUsecase) {
+		u.hintPenalty = penalty
+	}
+}
+
+// Record stores an attempt, answered now unless it says otherwise, and
+// deducts the hints the player used for it from its score.
+func (u *attemptUsecase) Record(attempt *domain.Attempt) error {
+	attempt.Player = strings.TrimSpace(attempt.Player)
+	if attempt.AnsweredAt.IsZero() {
+		attempt.AnsweredAt = time.Now()
+	}
+	if err := helper.Validate(attempt); err != nil {
+		return err
+	}
+
+	hints, err := u.attemptRepository.PendingHints(attempt.Player, attempt.QuestionNumber)
+	if err != nil {
+		return err
+	}
+	attempt.HintsUsed = hints
+	if hints > 0 && attempt.Score > 0 {
+		attempt.Score = math.Max(0, attempt.Score-u.hintPenalty*float64(hints))
+	}
+	return u.attemptRepository.Store(attempt)
+}
+
+// UseHint reveals to player the next hint of question they have not seen
+// since their last attempt at it.
+func (u *attemptUsecase) UseHint(player string, question domain.Question) (domain.HintUse, error) {
+	use := domain.HintUse{Player: strings.TrimSpace(player), QuestionNumber: question.Number, Total: len(question.Hints)}
+	if len(question.Hints) == 0 {
+		return use, domain.Errorf(domain.ErrNotFound, "Question no %s has no hints", question.Number)
+	}
+	if err := helper.Validate(use); err != nil {
+		return use, err
+	}
+
+	used, err := u.attemptRepository.PendingHints(use.Player, use.QuestionNumber)
+	if err != nil {
+		return use, err
+	}
+	if used >= len(question.Hints) {
+		return use, domain.Errorf(domain.ErrNotFound, "No more hints for question no %s, all %d shown", question.Number, len(question.Hints))
+	}
+
+	use.Hint = used + 1
+	use.Content = question.Hints[used]
+	use.UsedAt = time.Now()
+	if err := u.attemptRepository.StoreHintUse(&use); err != nil {
+		return use, err
+	}
+	return use, nil
+}
+
+func (u *attemptUsecase) History(filter domain.AttemptFilter) ([]*domain.Attempt, error) {
+	return u.attemptRepository.Fetch(filter)
+}
+
+// Score sums up the attempts of player, overall and per question.
+func (u *attemptUsecase) Score(player string) (domain.PlayerScore, error) {
+	score := domain.PlayerScore{Player: player}
+	if player == "" {
+		return score, domain.Invalid("player", "Player is required")
+	}
+
+	questions, err := u.attemptRepository.ScoreByQuestion(player)
+	if err != nil {
+		return score, err
+	}
+	for _, q := range questions {
+		score.Attempts += q.Attempts
+		score.Correct += q.Correct
+		score.Score += q.Score
+	}
+	score.Questions = questions
+	return score, nil
+}
+
+// Leaderboard ranks at most limit players by accuracy, points or streak,
+// counting the attempts of the last since, or all of them when since is
+// zero.
+func (u *attemptUsecase) Leaderboard(by string, since time.Duration, limit int) ([]domain.LeaderboardEntry, error) {
+	switch by {
+	case domain.LeaderboardByAccuracy, domain.LeaderboardByPoints, domain.LeaderboardByStreak:
+	default:
+		return nil, domain.Invalid("by", "Leaderboard can be by %s, %s or %s",
+			domain.LeaderboardByAccuracy, domain.LeaderboardByPoints, domain.LeaderboardByStreak)
+	}
+	if limit <= 0 {
+		return nil, domain.Invalid("limit", "Limit must be greater than 0")
+	}
+
+	query := domain.LeaderboardQuery{By: by, Limit: limit}
+	if since > 0 {
+		query.Since = time.Now().Add(-since)
+	}
+	entries, err := u.attemptRepository.Leaderboard(query)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].Rank = i + 1
+		if entries[i].Attempts > 0 {
+			entries[i].Accuracy = float64(entries[i].Correct) / float64(entries[i].Attempts)
+		}
+	}
+	return entries, nil
+}
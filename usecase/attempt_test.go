@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"fmt"
+	"quiz_master/domain"
+	"quiz_master/domain/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecord(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockAttemptRepo := new(mocks.AttemptRepository)
+		mockAttemptRepo.On("PendingHints", "alice", "1").Return(0, nil).Once()
+		mockAttemptRepo.On("Store", mock.MatchedBy(func(a *domain.Attempt) bool {
+			return a.Player == "alice" && !a.AnsweredAt.IsZero()
+		})).Return(nil).Once()
+		u := NewAttemptUsecase(mockAttemptRepo)
+		err := u.Record(&domain.Attempt{Player: " alice ", QuestionNumber: "1", Answer: "2", Correct: true, Score: 1})
+		assert.NoError(t, err)
+		mockAttemptRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockAttemptRepo := new(mocks.AttemptRepository)
+		u := NewAttemptUsecase(mockAttemptRepo)
+		err := u.Record(&domain.Attempt{QuestionNumber: "1", Answer: "2"})
+		assert.Error(t, err)
+		assert.Equal(t, "Player is required", err.Error())
+		mockAttemptRepo.AssertExpectations(t)
+	})
+}
+
+func TestRecord_HintPenalty(t *testing.T) {
+	tests := []struct {
+		name      string
+		score     float64
+		hints     int
+		wantScore float64
+	}{
+		{"no hints", 1, 0, 1},
+		{"one hint", 1, 1, 0.75},
+		{"partial answer", 0.5, 1, 0.25},
+		{"never below zero", 1, 5, 0},
+		{"wrong answer", 0, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAttemptRepo := new(mocks.AttemptRepository)
+			mockAttemptRepo.On("PendingHints", "alice", "1").Return(tt.hints, nil).Once()
+			mockAttemptRepo.On("Store", mock.Anything).Return(nil).Once()
+			u := NewAttemptUsecase(mockAttemptRepo, WithHintPenalty(0.25))
+			attempt := &domain.Attempt{Player: "alice", QuestionNumber: "1", Answer: "2", Correct: tt.score == 1, Score: tt.score}
+			assert.NoError(t, u.Record(attempt))
+			assert.Equal(t, tt.hints, attempt.HintsUsed)
+			assert.InDelta(t, tt.wantScore, attempt.Score, 1e-9)
+			mockAttemptRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUseHint(t *testing.T) {
+	question := domain.Question{Number: "1", Hints: []string{"It's in Europe", "It starts with P"}}
+
+	t.Run("success", func(t *testing.T) {
+		mockAttemptRepo := new(mocks.AttemptRepository)
+		mockAttemptRepo.On("PendingHints", "alice", "1").Return(1, nil).Once()
+		mockAttemptRepo.On("StoreHintUse", mock.MatchedBy(func(use *domain.HintUse) bool {
+			return use.Player == "alice" && use.Hint == 2 && !use.UsedAt.IsZero()
+		})).Return(nil).Once()
+		u := NewAttemptUsecase(mockAttemptRepo)
+		use, err := u.UseHint(" alice ", question)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, use.Hint)
+		assert.Equal(t, 2, use.Total)
+		assert.Equal(t, "It starts with P", use.Content)
+		mockAttemptRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			question domain.Question
+			pending  int
+			wantErr  string
+		}{
+			{"no hints", domain.Question{Number: "1"}, -1, "Question no 1 has no hints"},
+			{"all shown", question, 2, "No more hints for question no 1, all 2 shown"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockAttemptRepo := new(mocks.AttemptRepository)
+				if tt.pending >= 0 {
+					mockAttemptRepo.On("PendingHints", "alice", "1").Return(tt.pending, nil).Once()
+				}
+				u := NewAttemptUsecase(mockAttemptRepo)
+				_, err := u.UseHint("alice", tt.question)
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+				mockAttemptRepo.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestScore(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockAttemptRepo := new(mocks.AttemptRepository)
+		questions := []domain.QuestionScore{
+			{QuestionNumber: "1", Attempts: 1, Correct: 1, Score: 1},
+			{QuestionNumber: "2", Attempts: 3, Correct: 1, Score: 1.5},
+		}
+		mockAttemptRepo.On("ScoreByQuestion", "alice").Return(questions, nil).Once()
+		u := NewAttemptUsecase(mockAttemptRepo)
+		score, err := u.Score("alice")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.PlayerScore{Player: "alice", Attempts: 4, Correct: 2, Score: 2.5, Questions: questions}, score)
+		mockAttemptRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockAttemptRepo := new(mocks.AttemptRepository)
+		mockAttemptRepo.On("ScoreByQuestion", "alice").Return(nil, fmt.Errorf("some error")).Once()
+		u := NewAttemptUsecase(mockAttemptRepo)
+		_, err := u.Score("alice")
+		assert.Error(t, err)
+		mockAttemptRepo.AssertExpectations(t)
+	})
+}
+
+func TestLeaderboard(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockAttemptRepo := new(mocks.AttemptRepository)
+		mockAttemptRepo.On("Leaderboard", mock.MatchedBy(func(q domain.LeaderboardQuery) bool {
+			return q.By == domain.LeaderboardByStreak && q.Limit == 5 && time.Since(q.Since) > 7*24*time.Hour-time.Minute
+		})).Return([]domain.LeaderboardEntry{
+			{Player: "alice", Attempts: 4, Correct: 3, Points: 3, Streak: 2},
+			{Player: "bob", Attempts: 2, Correct: 1, Points: 1.5, Streak: 1},
+		}, nil).Once()
+		u := NewAttemptUsecase(mockAttemptRepo)
+		entries, err := u.Leaderboard(domain.LeaderboardByStreak, 7*24*time.Hour, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.LeaderboardEntry{
+			{Rank: 1, Player: "alice", Attempts: 4, Correct: 3, Accuracy: 0.75, Points: 3, Streak: 2},
+			{Rank: 2, Player: "bob", Attempts: 2, Correct: 1, Accuracy: 0.5, Points: 1.5, Streak: 1},
+		}, entries)
+		mockAttemptRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockAttemptRepo := new(mocks.AttemptRepository)
+		u := NewAttemptUsecase(mockAttemptRepo)
+		_, err := u.Leaderboard("luck", 0, 5)
+		assert.Error(t, err)
+		assert.Equal(t, "Leaderboard can be by accuracy, points or streak", err.Error())
+		mockAttemptRepo.AssertExpectations(t)
+	})
+}
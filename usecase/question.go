@@ -2,64 +2,553 @@ package usecase
 
 import (
 	"errors"
-	"fmt"
-	"quiz_master/builder"
+	"math/big"
 	"quiz_master/domain"
+	"quiz_master/dto"
+	"quiz_master/number"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	helper "quiz_master/helper"
-
-	ntw "moul.io/number-to-words"
 )
 
 type questionUsecase struct {
 	questionRepository domain.QuestionRepository
+	normalize          helper.NormalizeOptions
+	langs              []string
+	fuzzyAccept        bool
+}
+
+type Option func(*questionUsecase)
+
+func NewQuestionUsecase(repo domain.QuestionRepository, options ...Option) domain.QuestionUsecase {
+	u := &questionUsecase{repo, helper.DefaultNormalizeOptions, []string{number.English}, true}
+	for _, o := range options {
+		o(u)
+	}
+	return u
+}
+
+// WithNormalization sets which differences text answers may have from the
+// stored answer.
+func WithNormalization(normalize helper.NormalizeOptions) Option {
+	return func(u *questionUsecase) {
+		u.normalize = normalize
+	}
+}
+
+// WithLanguages sets the languages whose number words answer numeric
+// questions that have no language of their own. The first one is also used
+// for messages.
+func WithLanguages(langs []string) Option {
+	return func(u *questionUsecase) {
+		u.langs = langs
+	}
+}
+
+// WithFuzzyAccept sets whether a text answer within the question's typo
+// threshold counts as correct, or is only reported as almost right.
+func WithFuzzyAccept(accept bool) Option {
+	return func(u *questionUsecase) {
+		u.fuzzyAccept = accept
+	}
 }
 
-func NewQuestionUsecase(repo domain.QuestionRepository) domain.QuestionUsecase {
-	return &questionUsecase{repo}
+func (u *questionUsecase) Store(q *domain.Question) error {
+	if q.AnswerType == "" {
+		q.AnswerType = domain.AnswerTypeNumeric
+	}
+
+	if err := u.validateQuestion(q); err != nil {
+		return err
+	}
+
+	// the unique number in the database refuses a question that exists,
+	// checking beforehand would race with concurrent creates
+	return u.numberTaken(q.Number, u.questionRepository.Store(q))
 }
 
-func (u *questionUsecase) Store(args []string) error {
-	q := builder.NewQuestion(
-		builder.SetNumber(args[0]),
-		builder.SetQuestion(args[1]),
-		builder.SetAnswer(args[2]),
-	)
+// numberTaken tells, when err is the repository refusing number as already
+// taken, whether it is taken by a question in the trash.
+func (u *questionUsecase) numberTaken(number string, err error) error {
+	if !errors.Is(err, domain.ErrAlreadyExists) {
+		return err
+	}
+	_, terr := u.questionRepository.GetTrashedByNumber(number)
+	switch {
+	case terr == nil:
+		return domain.Errorf(domain.ErrAlreadyExists, "Question no %s is in the trash, restore or purge it first!", number)
+	case errors.Is(terr, domain.ErrNotFound):
+		return err
+	}
+	return terr
+}
+
+// Upsert stores q, overwriting the question that has its number if any,
+// and tells whether q was created.
+func (u *questionUsecase) Upsert(q *domain.Question) (bool, error) {
+	if q.AnswerType == "" {
+		q.AnswerType = domain.AnswerTypeNumeric
+	}
 
+	if err := u.validateQuestion(q); err != nil {
+		return false, err
+	}
+	return u.questionRepository.Upsert(q)
+}
+
+// validateQuestion runs the struct tags and the checks that depend on the
+// answer type.
+func (u *questionUsecase) validateQuestion(q *domain.Question) error {
+	if err := validateChoices(q); err != nil {
+		return err
+	}
 	if err := helper.Validate(q); err != nil {
 		return err
 	}
+	if err := u.validateGrading(q); err != nil {
+		return err
+	}
+	if q.Fuzzy > 0 && q.AnswerType != domain.AnswerTypeText {
+		return domain.Invalid("fuzzy", "Only text questions can have fuzzy matching")
+	}
+	if q.Lang != "" && !number.Supported(q.Lang) {
+		return domain.Invalid("lang", "Lang must be one of %s", strings.Join(number.Languages(), ", "))
+	}
+	if q.AnswerType == domain.AnswerTypeNumeric {
+		if _, err := number.Parse(q.Answer, u.languages(*q)...); err != nil {
+			return domain.Invalid("answer", "Answer must be a valid numeric value")
+		}
+	}
+	return nil
+}
 
-	existedQuestion, _ := u.questionRepository.GetByNumber(args[0])
-	if existedQuestion != (domain.Question{}) {
-		return fmt.Errorf("Question no " + args[0] + " already existed!")
+// validateGrading checks the grading rule of a numeric question and drops
+// the values its rule does not use.
+func (u *questionUsecase) validateGrading(q *domain.Question) error {
+	g := &q.Grading
+	if g.Rule == domain.GradingExact {
+		*g = domain.Grading{}
+		return nil
+	}
+	if q.AnswerType != domain.AnswerTypeNumeric {
+		return domain.Invalid("grading", "Only numeric questions can have a grading rule")
 	}
 
-	return u.questionRepository.Store(q)
+	switch g.Rule {
+	case domain.GradingAbsolute, domain.GradingRelative:
+		g.Min, g.Max = "", ""
+		tolerance, err := number.Parse(g.Tolerance)
+		if err != nil {
+			return domain.Invalid("tolerance", "Tolerance must be a valid numeric value")
+		}
+		if tolerance.Sign() < 0 {
+			return domain.Invalid("tolerance", "Tolerance must not be negative")
+		}
+	case domain.GradingRange:
+		g.Tolerance = ""
+		min, err := number.Parse(g.Min)
+		if err != nil {
+			return domain.Invalid("range", "Range minimum must be a valid numeric value")
+		}
+		max, err := number.Parse(g.Max)
+		if err != nil {
+			return domain.Invalid("range", "Range maximum must be a valid numeric value")
+		}
+		if min.Cmp(max) > 0 {
+			return domain.Invalid("range", "Range minimum must not be greater than its maximum")
+		}
+		answer, err := number.Parse(q.Answer, u.languages(*q)...)
+		if err == nil && (answer.Cmp(min) < 0 || answer.Cmp(max) > 0) {
+			return domain.Invalid("answer", "Answer must lie within the range")
+		}
+	default:
+		return domain.Invalid("grading", "Grading rule must be one of %s, %s or %s",
+			domain.GradingAbsolute, domain.GradingRelative, domain.GradingRange)
+	}
+	return nil
+}
+
+// validateChoices checks the options of a multiple choice question and
+// derives its answer, the comma separated labels of the correct options.
+func validateChoices(q *domain.Question) error {
+	if q.AnswerType != domain.AnswerTypeChoice {
+		if len(q.Choices) > 0 {
+			return domain.Invalid("choices", "Only choice questions can have choices")
+		}
+		return nil
+	}
+	if len(q.Choices) < 2 {
+		return domain.Invalid("choices", "A choice question needs at least 2 choices")
+	}
+
+	seen := map[string]bool{}
+	correct := []string{}
+	for _, c := range q.Choices {
+		label := strings.ToUpper(c.Label)
+		if seen[label] {
+			return domain.Invalid("choices", "Choice %s is given twice", c.Label)
+		}
+		seen[label] = true
+		if c.Correct {
+			correct = append(correct, c.Label)
+		}
+	}
+	if len(correct) == 0 {
+		return domain.Invalid("choices", "At least one choice must be correct")
+	}
+	q.Answer = strings.Join(correct, ",")
+	return nil
 }
 
-func (u *questionUsecase) GetAll() ([]*domain.Question, error) {
-	return u.questionRepository.GetAll()
+// sameHints tells whether two lists hold the same hints in the same order.
+func sameHints(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// formatHints lists hints for a QuestionChange, "none" when there are none.
+func formatHints(hints []string) string {
+	if len(hints) == 0 {
+		return "none"
+	}
+	return strings.Join(hints, " | ")
+}
+
+// markCorrect flags the choices whose labels are listed in answer, a comma
+// separated list such as "A,C", as the correct ones.
+func markCorrect(choices []domain.Choice, answer string) error {
+	correct := map[string]bool{}
+	for _, label := range strings.Split(answer, ",") {
+		correct[strings.ToUpper(strings.TrimSpace(label))] = true
+	}
+	for i := range choices {
+		label := strings.ToUpper(choices[i].Label)
+		choices[i].Correct = correct[label]
+		delete(correct, label)
+	}
+	for label := range correct {
+		return domain.Invalid("correct", "Choice %s does not exist", label)
+	}
+	return nil
+}
+
+func (u *questionUsecase) GetAll(filter domain.QuestionFilter) ([]*domain.Question, error) {
+	tags, err := normalizeTags(filter.Tags)
+	if err != nil {
+		return nil, err
+	}
+	filter.Tags = tags
+	return u.questionRepository.GetAll(domain.QuestionListOptions{Filter: filter})
+}
+
+// List returns the page of questions picked by options, with how many
+// questions there are on all pages.
+func (u *questionUsecase) List(options domain.QuestionListOptions) (domain.QuestionPage, error) {
+	switch {
+	case options.Sort != "" && options.Sort != domain.SortNumber && options.Sort != domain.SortCreated && options.Sort != domain.SortQuestion:
+		return domain.QuestionPage{}, domain.Invalid("sort", "Sort must be one of %s, %s or %s", domain.SortNumber, domain.SortCreated, domain.SortQuestion)
+	case options.Limit < 0:
+		return domain.QuestionPage{}, domain.Invalid("limit", "Limit must be 0 or greater")
+	case options.Offset < 0:
+		return domain.QuestionPage{}, domain.Invalid("offset", "Offset must be 0 or greater")
+	}
+	tags, err := normalizeTags(options.Filter.Tags)
+	if err != nil {
+		return domain.QuestionPage{}, err
+	}
+	options.Filter.Tags = tags
+
+	questions, err := u.questionRepository.GetAll(options)
+	if err != nil {
+		return domain.QuestionPage{}, err
+	}
+	total, err := u.questionRepository.Count(options.Filter)
+	if err != nil {
+		return domain.QuestionPage{}, err
+	}
+	return domain.QuestionPage{Questions: questions, Offset: options.Offset, Total: total}, nil
 }
 
 func (u *questionUsecase) GetByNumber(number string) (domain.Question, error) {
 	return u.questionRepository.GetByNumber(number)
 }
 
-func (u *questionUsecase) AnswerQuestion(args []string) error {
-	answer := args[1]
-	question, err := u.questionRepository.GetByNumber(args[0])
+// AnswerQuestion grades an answer. A wrong answer is not an error, only a
+// missing question or a failing repository are.
+func (u *questionUsecase) AnswerQuestion(number string, answer string) (domain.AnswerResult, error) {
+	question, err := u.questionRepository.GetByNumber(number)
 	if err != nil {
-		return err
+		return domain.AnswerResult{}, err
+	}
+
+	result := u.grade(question, answer)
+	result.Lang = u.languages(question)[0]
+	result.Feedback = helper.Message(result.Lang, feedback(result))
+	result.Explanation = question.Explanation
+	return result, nil
+}
+
+// grade checks an answer against the question's own answer, then against
+// its aliases. Text answers with no more typos than the question allows are
+// accepted as close, or only reported as almost right when near misses do
+// not count.
+func (u *questionUsecase) grade(question domain.Question, answer string) domain.AnswerResult {
+	if question.AnswerType == domain.AnswerTypeChoice {
+		if u.matchChoices(question.Choices, answer) {
+			return domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: question.Answer}
+		}
+		return domain.AnswerResult{Outcome: domain.OutcomeIncorrect}
 	}
-	convAnswer, _ := strconv.Atoi(question.Answer)
-	if strings.ToLower(answer) != ntw.IntegerToEnUs(convAnswer) && question.Answer != answer {
-		return errors.New("Wrong Answer!")
+
+	if u.matchAnswer(question, answer) {
+		return domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: question.Answer}
 	}
+	for _, alias := range question.Aliases {
+		if helper.NormalizeText(answer, u.normalize) == helper.NormalizeText(alias, u.normalize) {
+			return domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: alias, Alias: true}
+		}
+	}
+	if near, ok := u.closestAnswer(question, answer); ok {
+		if !u.fuzzyAccept {
+			return domain.AnswerResult{Outcome: domain.OutcomeIncorrect, Distance: near.Distance}
+		}
+		return near
+	}
+	return domain.AnswerResult{Outcome: domain.OutcomeIncorrect}
+}
 
-	return nil
+// feedback picks the message telling the player how their answer was graded.
+func feedback(result domain.AnswerResult) string {
+	switch result.Outcome {
+	case domain.OutcomeCorrect:
+		if result.Distance > 0 {
+			return helper.MessageCloseAccepted
+		}
+		return helper.MessageCorrect
+	case domain.OutcomePartial:
+		return helper.MessagePartial
+	}
+	if result.Distance > 0 {
+		return helper.MessageAlmost
+	}
+	return helper.MessageWrongAnswer
+}
+
+// closestAnswer finds the accepted answer of a text question with the fewest
+// typos from answer, ok is false when none is within the question's
+// threshold.
+func (u *questionUsecase) closestAnswer(question domain.Question, answer string) (match domain.AnswerResult, ok bool) {
+	if question.AnswerType != domain.AnswerTypeText || question.Fuzzy <= 0 {
+		return match, false
+	}
+	answer = helper.NormalizeText(answer, u.normalize)
+	match.Distance = question.Fuzzy + 1
+	for i, accepted := range append([]string{question.Answer}, question.Aliases...) {
+		distance := helper.EditDistance(answer, helper.NormalizeText(accepted, u.normalize))
+		if distance < match.Distance {
+			match = domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: accepted, Alias: i > 0, Distance: distance}
+		}
+	}
+	return match, match.Distance <= question.Fuzzy
+}
+
+// languages returns the languages a question is answered in, its own or
+// else the installation's.
+func (u *questionUsecase) languages(question domain.Question) []string {
+	if question.Lang != "" {
+		return []string{question.Lang}
+	}
+	if len(u.langs) == 0 {
+		return []string{number.English}
+	}
+	return u.langs
+}
+
+func (u *questionUsecase) matchAnswer(question domain.Question, answer string) bool {
+	if question.AnswerType == domain.AnswerTypeText {
+		return helper.NormalizeText(answer, u.normalize) == helper.NormalizeText(question.Answer, u.normalize)
+	}
+	return u.matchNumber(question, answer)
+}
+
+// matchNumber grades a numeric answer by the question's grading rule.
+// Tolerances and bounds were validated when the question was stored.
+func (u *questionUsecase) matchNumber(question domain.Question, answer string) bool {
+	g := question.Grading
+	if g.Rule == domain.GradingExact {
+		return number.Equal(answer, question.Answer, u.languages(question)...)
+	}
+	guess, err := number.Parse(answer, u.languages(question)...)
+	if err != nil {
+		return false
+	}
+
+	if g.Rule == domain.GradingRange {
+		min, _ := number.Parse(g.Min)
+		max, _ := number.Parse(g.Max)
+		return guess.Cmp(min) >= 0 && guess.Cmp(max) <= 0
+	}
+
+	expected, err := number.Parse(question.Answer, u.languages(question)...)
+	if err != nil {
+		return false
+	}
+	tolerance, _ := number.Parse(g.Tolerance)
+	if g.Rule == domain.GradingRelative {
+		tolerance.Mul(tolerance, new(big.Rat).Abs(expected))
+		tolerance.Quo(tolerance, big.NewRat(100, 1))
+	}
+	diff := new(big.Rat).Sub(guess, expected)
+	return diff.Abs(diff).Cmp(tolerance) <= 0
+}
+
+// matchChoices grades an answer to a multiple choice question. The answer
+// names options by letter or by text, several of them separated by commas,
+// and is only right when it names exactly the correct ones.
+func (u *questionUsecase) matchChoices(choices []domain.Choice, answer string) bool {
+	selected := map[int]bool{}
+	if i := u.findChoice(choices, answer); i >= 0 {
+		// The text of an option may itself contain commas.
+		selected[i] = true
+	} else {
+		for _, token := range strings.Split(answer, ",") {
+			i := u.findChoice(choices, token)
+			if i < 0 {
+				return false
+			}
+			selected[i] = true
+		}
+	}
+
+	for i, c := range choices {
+		if c.Correct != selected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (u *questionUsecase) findChoice(choices []domain.Choice, token string) int {
+	token = strings.TrimSpace(token)
+	for i, c := range choices {
+		if strings.EqualFold(token, c.Label) {
+			return i
+		}
+	}
+	for i, c := range choices {
+		if helper.NormalizeText(token, u.normalize) == helper.NormalizeText(c.Text, u.normalize) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Update changes the fields set in request and returns what actually changed.
+// Renumbering fails if the new number is taken, including by a question in
+// the trash.
+func (u *questionUsecase) Update(number string, request *dto.RequestUpdateQuestion) ([]domain.QuestionChange, error) {
+	question, err := u.questionRepository.GetByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := question
+	updated.Choices = append([]domain.Choice(nil), question.Choices...)
+	if request.Number != nil {
+		updated.Number = *request.Number
+	}
+	if request.Question != nil {
+		updated.Question = *request.Question
+	}
+	if request.Answer != nil {
+		updated.Answer = *request.Answer
+	}
+	if request.AnswerType != nil {
+		updated.AnswerType = *request.AnswerType
+	}
+	if request.Lang != nil {
+		updated.Lang = *request.Lang
+	}
+	if request.Fuzzy != nil {
+		updated.Fuzzy = *request.Fuzzy
+	} else if updated.AnswerType != domain.AnswerTypeText {
+		updated.Fuzzy = 0
+	}
+	if request.TimeLimit != nil {
+		updated.TimeLimit = *request.TimeLimit
+	}
+	if request.Hints != nil {
+		updated.Hints = *request.Hints
+	}
+	if request.Explanation != nil {
+		updated.Explanation = *request.Explanation
+	}
+	if request.Grading != nil {
+		updated.Grading = domain.Grading(*request.Grading)
+	} else if updated.AnswerType != domain.AnswerTypeNumeric {
+		updated.Grading = domain.Grading{}
+	}
+	if updated.AnswerType != domain.AnswerTypeChoice {
+		updated.Choices = nil
+	} else if request.Answer != nil {
+		if err := markCorrect(updated.Choices, *request.Answer); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.validateQuestion(&updated); err != nil {
+		return nil, err
+	}
+
+	changes := []domain.QuestionChange{}
+	if updated.Number != question.Number {
+		changes = append(changes, domain.QuestionChange{Field: "number", Old: question.Number, New: updated.Number})
+	}
+	if updated.Question != question.Question {
+		changes = append(changes, domain.QuestionChange{Field: "question", Old: question.Question, New: updated.Question})
+	}
+	if updated.Answer != question.Answer {
+		changes = append(changes, domain.QuestionChange{Field: "answer", Old: question.Answer, New: updated.Answer})
+	}
+	if updated.AnswerType != question.AnswerType {
+		changes = append(changes, domain.QuestionChange{Field: "answer_type", Old: question.AnswerType, New: updated.AnswerType})
+	}
+	if updated.Lang != question.Lang {
+		changes = append(changes, domain.QuestionChange{Field: "lang", Old: question.Lang, New: updated.Lang})
+	}
+	if updated.Grading != question.Grading {
+		changes = append(changes, domain.QuestionChange{Field: "grading", Old: helper.FormatGrading(question.Grading), New: helper.FormatGrading(updated.Grading)})
+	}
+	if updated.Fuzzy != question.Fuzzy {
+		changes = append(changes, domain.QuestionChange{Field: "fuzzy", Old: strconv.Itoa(question.Fuzzy), New: strconv.Itoa(updated.Fuzzy)})
+	}
+	if !sameHints(updated.Hints, question.Hints) {
+		changes = append(changes, domain.QuestionChange{Field: "hints", Old: formatHints(question.Hints), New: formatHints(updated.Hints)})
+	}
+	if updated.Explanation != question.Explanation {
+		changes = append(changes, domain.QuestionChange{Field: "explanation", Old: question.Explanation, New: updated.Explanation})
+	}
+	if updated.TimeLimit != question.TimeLimit {
+		changes = append(changes, domain.QuestionChange{Field: "time_limit", Old: helper.FormatTimeLimit(question.TimeLimit), New: helper.FormatTimeLimit(updated.TimeLimit)})
+	}
+	if len(changes) == 0 {
+		return changes, nil
+	}
+
+	if err := u.questionRepository.Update(&updated); err != nil {
+		return nil, u.numberTaken(updated.Number, err)
+	}
+	return changes, nil
 }
 
 func (u *questionUsecase) Destroy(number string) error {
@@ -69,3 +558,142 @@ func (u *questionUsecase) Destroy(number string) error {
 	}
 	return u.questionRepository.Destroy(number)
 }
+
+func (u *questionUsecase) GetTrashed() ([]*domain.Question, error) {
+	return u.questionRepository.GetTrashed()
+}
+
+func (u *questionUsecase) Restore(number string) error {
+	_, err := u.questionRepository.GetTrashedByNumber(number)
+	if err != nil {
+		return err
+	}
+	return u.questionRepository.Restore(number)
+}
+
+// PurgeTrash permanently deletes questions that have been in the trash for
+// at least olderThan, zero empties the whole trash.
+func (u *questionUsecase) PurgeTrash(olderThan time.Duration) (int64, error) {
+	return u.questionRepository.Purge(time.Now().Add(-olderThan))
+}
+
+// AddAlias makes alias another accepted answer of the question. Aliases are
+// compared with the same normalization as text answers.
+func (u *questionUsecase) AddAlias(number string, alias string) error {
+	alias = strings.TrimSpace(alias)
+	if alias == "" {
+		return domain.Invalid("alias", "Alias is required")
+	}
+	question, err := u.questionRepository.GetByNumber(number)
+	if err != nil {
+		return err
+	}
+	if question.AnswerType == domain.AnswerTypeChoice {
+		return domain.Invalid("alias", "Choice questions cannot have aliases")
+	}
+	for _, a := range question.Aliases {
+		if helper.NormalizeText(a, u.normalize) == helper.NormalizeText(alias, u.normalize) {
+			return domain.Errorf(domain.ErrAlreadyExists, "Question no %s already accepts %s", number, a)
+		}
+	}
+	return u.questionRepository.AddAlias(question.ID, alias)
+}
+
+func (u *questionUsecase) RemoveAlias(number string, alias string) error {
+	question, err := u.questionRepository.GetByNumber(number)
+	if err != nil {
+		return err
+	}
+	for _, a := range question.Aliases {
+		if a == alias {
+			return u.questionRepository.RemoveAlias(question.ID, alias)
+		}
+	}
+	return domain.Errorf(domain.ErrNotFound, "Question no %s has no alias %s", number, alias)
+}
+
+// TagQuestion adds tags to a question and returns the ones it did not have
+// yet. Tags are lowercased.
+func (u *questionUsecase) TagQuestion(number string, tags []string) ([]string, error) {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	question, err := u.questionRepository.GetByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+
+	has := map[string]bool{}
+	for _, t := range question.Tags {
+		has[t] = true
+	}
+	added := []string{}
+	for _, t := range tags {
+		if !has[t] {
+			added = append(added, t)
+		}
+	}
+	if len(added) == 0 {
+		return added, nil
+	}
+	return added, u.questionRepository.AddTags(question.ID, added)
+}
+
+func (u *questionUsecase) UntagQuestion(number string, tags []string) error {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	question, err := u.questionRepository.GetByNumber(number)
+	if err != nil {
+		return err
+	}
+
+	has := map[string]bool{}
+	for _, t := range question.Tags {
+		has[t] = true
+	}
+	for _, t := range tags {
+		if !has[t] {
+			return domain.Errorf(domain.ErrNotFound, "Question no %s has no tag %s", number, t)
+		}
+	}
+	return u.questionRepository.RemoveTags(question.ID, tags)
+}
+
+func (u *questionUsecase) ListTags() ([]domain.TagCount, error) {
+	return u.questionRepository.CountTags()
+}
+
+// Search returns the questions matching query, best first.
+func (u *questionUsecase) Search(query domain.SearchQuery) ([]domain.SearchResult, error) {
+	query.Terms = strings.TrimSpace(query.Terms)
+	if query.Terms == "" {
+		return nil, domain.Invalid("terms", "Search terms are required")
+	}
+	if query.Limit < 0 {
+		return nil, domain.Invalid("limit", "Limit must be 0 or greater")
+	}
+	return u.questionRepository.Search(query)
+}
+
+// normalizeTags lowercases and trims tags, dropping duplicates.
+func normalizeTags(tags []string) ([]string, error) {
+	var normalized []string
+	seen := map[string]bool{}
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		switch {
+		case t == "":
+			return nil, domain.Invalid("tag", "Tag is required")
+		case utf8.RuneCountInString(t) > 50:
+			return nil, domain.Invalid("tag", "Tag %s is longer than 50 characters", t)
+		case seen[t]:
+			continue
+		}
+		seen[t] = true
+		normalized = append(normalized, t)
+	}
+	return normalized, nil
+}
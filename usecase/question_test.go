@@ -6,7 +6,10 @@ import (
 	"quiz_master/builder"
 	"quiz_master/domain"
 	"quiz_master/domain/mocks"
+	"quiz_master/dto"
+	"quiz_master/helper"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -25,7 +28,7 @@ func TestGetAll(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockQuestionRepo.On("GetAll", mock.Anything).Return(mockListQuestion, nil).Once()
 		u := NewQuestionUsecase(mockQuestionRepo)
-		questions, err := u.GetAll()
+		questions, err := u.GetAll(domain.QuestionFilter{})
 		assert.NoError(t, err)
 		assert.Len(t, questions, len(mockListQuestion))
 
@@ -37,9 +40,12 @@ func TestStore_FailNumberValidation(t *testing.T) {
 	mockQuestionRepo := new(mocks.QuestionRepository)
 	t.Run("error-failed", func(t *testing.T) {
 		u := NewQuestionUsecase(mockQuestionRepo)
-		err := u.Store([]string{"abc", "lorem ipsum", "1"})
+		err := u.Store(builder.NewQuestion(builder.SetNumber("abc"), builder.SetQuestion("lorem ipsum"), builder.SetAnswer("1")))
 		assert.Error(t, err)
-		assert.Equal(t, err, fmt.Errorf("Number must be a valid numeric value"))
+		assert.Equal(t, err.Error(), "Number must be a valid numeric value")
+		var invalid *domain.ValidationError
+		assert.ErrorAs(t, err, &invalid)
+		assert.Equal(t, []domain.FieldError{{Field: "number", Message: "Number must be a valid numeric value"}}, invalid.Fields)
 
 		mockQuestionRepo.AssertExpectations(t)
 	})
@@ -49,7 +55,7 @@ func TestStore_FailAnswerValidation(t *testing.T) {
 	mockQuestionRepo := new(mocks.QuestionRepository)
 	t.Run("error-failed", func(t *testing.T) {
 		u := NewQuestionUsecase(mockQuestionRepo)
-		err := u.Store([]string{"100", "lorem ipsum", "ac"})
+		err := u.Store(builder.NewQuestion(builder.SetNumber("100"), builder.SetQuestion("lorem ipsum"), builder.SetAnswer("ac")))
 		assert.Error(t, err)
 		mockQuestionRepo.AssertExpectations(t)
 	})
@@ -58,11 +64,13 @@ func TestStore_FailAnswerValidation(t *testing.T) {
 func TestStore_FailQuestionAlreadyExisted(t *testing.T) {
 	mockQuestionRepo := new(mocks.QuestionRepository)
 	t.Run("error-failed", func(t *testing.T) {
-		mockQuestionRepo.On("GetByNumber", mock.Anything).Return(domain.Question{ID: 1}, fmt.Errorf("Question no 1 already existed!")).Once()
+		mockQuestionRepo.On("Store", mock.AnythingOfType("*domain.Question")).Return(domain.Errorf(domain.ErrAlreadyExists, "Question no 1 already existed!")).Once()
+		mockQuestionRepo.On("GetTrashedByNumber", "1").Return(domain.Question{}, domain.Errorf(domain.ErrNotFound, "Question not found in trash")).Once()
 		u := NewQuestionUsecase(mockQuestionRepo)
-		err := u.Store([]string{"1", "lorem ipsum", "1"})
+		err := u.Store(builder.NewQuestion(builder.SetNumber("1"), builder.SetQuestion("lorem ipsum"), builder.SetAnswer("1")))
 		assert.Error(t, err)
 		assert.Equal(t, err.Error(), "Question no 1 already existed!")
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
 		mockQuestionRepo.AssertExpectations(t)
 	})
 }
@@ -70,10 +78,9 @@ func TestStore_FailQuestionAlreadyExisted(t *testing.T) {
 func TestStore_Success(t *testing.T) {
 	mockQuestionRepo := new(mocks.QuestionRepository)
 	t.Run("success", func(t *testing.T) {
-		mockQuestionRepo.On("GetByNumber", mock.Anything).Return(domain.Question{}, nil).Once()
 		mockQuestionRepo.On("Store", mock.AnythingOfType("*domain.Question")).Return(nil).Once()
 		u := NewQuestionUsecase(mockQuestionRepo)
-		err := u.Store([]string{"1", "lorem ipsum", "1"})
+		err := u.Store(builder.NewQuestion(builder.SetNumber("1"), builder.SetQuestion("lorem ipsum"), builder.SetAnswer("1")))
 		assert.NoError(t, err)
 		mockQuestionRepo.AssertExpectations(t)
 	})
@@ -102,7 +109,7 @@ func TestAnswerQuestion_FailQuestionNotFound(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockQuestionRepo.On("GetByNumber", mock.Anything).Return(domain.Question{}, sql.ErrNoRows).Once()
 		u := NewQuestionUsecase(mockQuestionRepo)
-		err := u.AnswerQuestion([]string{"1", "1"})
+		_, err := u.AnswerQuestion("1", "1")
 		assert.Error(t, err)
 
 		mockQuestionRepo.AssertExpectations(t)
@@ -119,9 +126,9 @@ func TestAnswerQuestion_FailAnswerIsWrong(t *testing.T) {
 		)
 		mockQuestionRepo.On("GetByNumber", mock.Anything).Return(*mockQuestion, nil).Once()
 		u := NewQuestionUsecase(mockQuestionRepo)
-		err := u.AnswerQuestion([]string{"1", "3"})
-		assert.Error(t, err)
-		assert.Equal(t, err.Error(), "Wrong Answer!")
+		result, err := u.AnswerQuestion("1", "3")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AnswerResult{Outcome: domain.OutcomeIncorrect, Feedback: "Wrong Answer!", Lang: "en"}, result)
 
 		mockQuestionRepo.AssertExpectations(t)
 	})
@@ -137,8 +144,9 @@ func TestAnswerQuestion_Success(t *testing.T) {
 		)
 		mockQuestionRepo.On("GetByNumber", mock.Anything).Return(*mockQuestion, nil).Once()
 		u := NewQuestionUsecase(mockQuestionRepo)
-		err := u.AnswerQuestion([]string{"1", "2"})
+		result, err := u.AnswerQuestion("1", "2")
 		assert.NoError(t, err)
+		assert.Equal(t, domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "2", Feedback: "Correct!", Lang: "en"}, result)
 
 		mockQuestionRepo.AssertExpectations(t)
 	})
@@ -154,8 +162,9 @@ func TestAnswerQuestion_SuccessWithWordAnswer(t *testing.T) {
 		)
 		mockQuestionRepo.On("GetByNumber", mock.Anything).Return(*mockQuestion, nil).Once()
 		u := NewQuestionUsecase(mockQuestionRepo)
-		err := u.AnswerQuestion([]string{"1", "Two"})
+		result, err := u.AnswerQuestion("1", "Two")
 		assert.NoError(t, err)
+		assert.Equal(t, domain.OutcomeCorrect, result.Outcome)
 
 		mockQuestionRepo.AssertExpectations(t)
 	})
@@ -191,3 +200,955 @@ func TestDestroyQuestion_Success(t *testing.T) {
 		mockQuestionRepo.AssertExpectations(t)
 	})
 }
+
+func TestStore_FailQuestionInTrash(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("error-failed", func(t *testing.T) {
+		deletedAt := time.Now()
+		mockQuestionRepo.On("Store", mock.AnythingOfType("*domain.Question")).Return(domain.Errorf(domain.ErrAlreadyExists, "Question no 1 already existed!")).Once()
+		mockQuestionRepo.On("GetTrashedByNumber", "1").Return(domain.Question{ID: 1, Number: "1", DeletedAt: &deletedAt}, nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		err := u.Store(builder.NewQuestion(builder.SetNumber("1"), builder.SetQuestion("lorem ipsum"), builder.SetAnswer("1")))
+		assert.Error(t, err)
+		assert.Equal(t, err.Error(), "Question no 1 is in the trash, restore or purge it first!")
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestRestoreQuestion_FailNotInTrash(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestionRepo.On("GetTrashedByNumber", "1").Return(domain.Question{}, fmt.Errorf("Question not found in trash")).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		err := u.Restore("1")
+		assert.Error(t, err)
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestRestoreQuestion_Success(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("success", func(t *testing.T) {
+		deletedAt := time.Now()
+		mockQuestionRepo.On("GetTrashedByNumber", "1").Return(domain.Question{ID: 1, Number: "1", DeletedAt: &deletedAt}, nil).Once()
+		mockQuestionRepo.On("Restore", "1").Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		err := u.Restore("1")
+		assert.NoError(t, err)
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestPurgeTrash_Success(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("success", func(t *testing.T) {
+		olderThan := 30 * 24 * time.Hour
+		mockQuestionRepo.On("Purge", mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) >= olderThan && time.Since(before) < olderThan+time.Minute
+		})).Return(int64(2), nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		purged, err := u.PurgeTrash(olderThan)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), purged)
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdate_SuccessPartial(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("success", func(t *testing.T) {
+		mockQuestion := builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("lorem ipsum dolor?"),
+			builder.SetAnswer("2"),
+			builder.SetAnswerType(domain.AnswerTypeNumeric),
+		)
+		mockQuestion.ID = 5
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		mockQuestionRepo.On("Update", mock.MatchedBy(func(q *domain.Question) bool {
+			return q.ID == 5 && q.Number == "1" && q.Question == "lorem ipsum sit?" && q.Answer == "2"
+		})).Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		changes, err := u.Update("1", builder.NewRequestUpdate(builder.UpdateWithQuestion("lorem ipsum sit?")))
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.QuestionChange{{Field: "question", Old: "lorem ipsum dolor?", New: "lorem ipsum sit?"}}, changes)
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdate_SuccessRenumber(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("success", func(t *testing.T) {
+		mockQuestion := builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("lorem ipsum dolor?"),
+			builder.SetAnswer("2"),
+			builder.SetAnswerType(domain.AnswerTypeNumeric),
+		)
+		mockQuestion.ID = 5
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		mockQuestionRepo.On("Update", mock.MatchedBy(func(q *domain.Question) bool {
+			return q.ID == 5 && q.Number == "7"
+		})).Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		changes, err := u.Update("1", builder.NewRequestUpdate(builder.UpdateWithNumber("7")))
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.QuestionChange{{Field: "number", Old: "1", New: "7"}}, changes)
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdate_FailRenumberToExistingNumber(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestion := builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("lorem ipsum dolor?"),
+			builder.SetAnswer("2"),
+			builder.SetAnswerType(domain.AnswerTypeNumeric),
+		)
+		mockQuestion.ID = 5
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		mockQuestionRepo.On("Update", mock.AnythingOfType("*domain.Question")).Return(domain.Errorf(domain.ErrAlreadyExists, "Question no 2 already existed!")).Once()
+		mockQuestionRepo.On("GetTrashedByNumber", "2").Return(domain.Question{}, domain.Errorf(domain.ErrNotFound, "Question not found in trash")).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		changes, err := u.Update("1", builder.NewRequestUpdate(builder.UpdateWithNumber("2")))
+		assert.Error(t, err)
+		assert.Nil(t, changes)
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+		assert.Equal(t, "Question no 2 already existed!", err.Error())
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdate_FailRenumberToTrashedNumber(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestion := builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("lorem ipsum dolor?"),
+			builder.SetAnswer("2"),
+			builder.SetAnswerType(domain.AnswerTypeNumeric),
+		)
+		mockQuestion.ID = 5
+		deletedAt := time.Now()
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		mockQuestionRepo.On("Update", mock.AnythingOfType("*domain.Question")).Return(domain.Errorf(domain.ErrAlreadyExists, "Question no 2 already existed!")).Once()
+		mockQuestionRepo.On("GetTrashedByNumber", "2").Return(domain.Question{ID: 6, Number: "2", DeletedAt: &deletedAt}, nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		_, err := u.Update("1", builder.NewRequestUpdate(builder.UpdateWithNumber("2")))
+		assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+		assert.Equal(t, "Question no 2 is in the trash, restore or purge it first!", err.Error())
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdate_FailRenumberTrashLookup(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestion := builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("lorem ipsum dolor?"),
+			builder.SetAnswer("2"),
+			builder.SetAnswerType(domain.AnswerTypeNumeric),
+		)
+		mockQuestion.ID = 5
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		mockQuestionRepo.On("Update", mock.AnythingOfType("*domain.Question")).Return(domain.Errorf(domain.ErrAlreadyExists, "Question no 2 already existed!")).Once()
+		mockQuestionRepo.On("GetTrashedByNumber", "2").Return(domain.Question{}, domain.Errorf(domain.ErrUnavailable, "connection refused")).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		_, err := u.Update("1", builder.NewRequestUpdate(builder.UpdateWithNumber("2")))
+		assert.ErrorIs(t, err, domain.ErrUnavailable)
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdate_FailValidation(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestion := builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("lorem ipsum dolor?"),
+			builder.SetAnswer("2"),
+			builder.SetAnswerType(domain.AnswerTypeNumeric),
+		)
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		_, err := u.Update("1", builder.NewRequestUpdate(builder.UpdateWithAnswer("abc")))
+		assert.Error(t, err)
+		assert.Equal(t, "Answer must be a valid numeric value", err.Error())
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdate_NothingChanged(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("success", func(t *testing.T) {
+		mockQuestion := builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("lorem ipsum dolor?"),
+			builder.SetAnswer("2"),
+			builder.SetAnswerType(domain.AnswerTypeNumeric),
+		)
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		changes, err := u.Update("1", builder.NewRequestUpdate(builder.UpdateWithAnswer("2")))
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestStore_SuccessTextAnswer(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("success", func(t *testing.T) {
+		mockQuestionRepo.On("Store", mock.MatchedBy(func(q *domain.Question) bool {
+			return q.AnswerType == domain.AnswerTypeText && q.Answer == "Paris"
+		})).Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		err := u.Store(builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("What is the capital of France?"),
+			builder.SetAnswer("Paris"),
+			builder.SetAnswerType(domain.AnswerTypeText),
+		))
+		assert.NoError(t, err)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestStore_DefaultsToNumericAnswer(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("error-failed", func(t *testing.T) {
+		u := NewQuestionUsecase(mockQuestionRepo)
+		q := builder.NewQuestion(builder.SetNumber("1"), builder.SetQuestion("lorem ipsum"), builder.SetAnswer("Paris"))
+		err := u.Store(q)
+		assert.Error(t, err)
+		assert.Equal(t, "Answer must be a valid numeric value", err.Error())
+		assert.Equal(t, domain.AnswerTypeNumeric, q.AnswerType)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestAnswerQuestion_TextAnswer(t *testing.T) {
+	mockQuestion := builder.NewQuestion(
+		builder.SetNumber("1"),
+		builder.SetQuestion("Where is the Eiffel Tower?"),
+		builder.SetAnswer("Paris, Île-de-France"),
+		builder.SetAnswerType(domain.AnswerTypeText),
+	)
+	tests := []struct {
+		name      string
+		normalize helper.NormalizeOptions
+		answer    string
+		correct   bool
+	}{
+		{"exact", helper.NormalizeOptions{}, "Paris, Île-de-France", true},
+		{"dashes are not spaces", helper.DefaultNormalizeOptions, "  paris ile de   FRANCE ", false},
+		{"all normalizations drop dashes", helper.DefaultNormalizeOptions, "  paris ileDEfrance ", true},
+		{"all normalizations same words", helper.DefaultNormalizeOptions, " PARIS  île-de-france!", true},
+		{"diacritics", helper.NormalizeOptions{Diacritics: true}, "Paris, Ile-de-France", true},
+		{"case only", helper.NormalizeOptions{Case: true}, "paris, Île-de-France", true},
+		{"case off", helper.NormalizeOptions{}, "paris, Île-de-France", false},
+		{"punctuation", helper.NormalizeOptions{Punctuation: true}, "Paris Îlede-France.", true},
+		{"whitespace", helper.NormalizeOptions{Whitespace: true}, " Paris,  Île-de-France ", true},
+		{"wrong", helper.DefaultNormalizeOptions, "Lyon", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQuestionRepo := new(mocks.QuestionRepository)
+			mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+			u := NewQuestionUsecase(mockQuestionRepo, WithNormalization(tt.normalize))
+			result, err := u.AnswerQuestion("1", tt.answer)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.correct, result.Outcome == domain.OutcomeCorrect)
+			mockQuestionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdate_SuccessChangeAnswerType(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("success", func(t *testing.T) {
+		mockQuestion := builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("What is the capital of France?"),
+			builder.SetAnswer("2"),
+			builder.SetAnswerType(domain.AnswerTypeNumeric),
+		)
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		mockQuestionRepo.On("Update", mock.AnythingOfType("*domain.Question")).Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		changes, err := u.Update("1", builder.NewRequestUpdate(
+			builder.UpdateWithAnswer("Paris"),
+			builder.UpdateWithAnswerType(domain.AnswerTypeText),
+		))
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.QuestionChange{
+			{Field: "answer", Old: "2", New: "Paris"},
+			{Field: "answer_type", Old: domain.AnswerTypeNumeric, New: domain.AnswerTypeText},
+		}, changes)
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestStore_SuccessChoiceDerivesAnswer(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("success", func(t *testing.T) {
+		mockQuestionRepo.On("Store", mock.MatchedBy(func(q *domain.Question) bool {
+			return q.AnswerType == domain.AnswerTypeChoice && q.Answer == "A,C" && len(q.Choices) == 3
+		})).Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		err := u.Store(builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("Which of these are prime?"),
+			builder.SetAnswerType(domain.AnswerTypeChoice),
+			builder.SetChoices([]domain.Choice{
+				{Label: "A", Text: "2", Correct: true},
+				{Label: "B", Text: "4"},
+				{Label: "C", Text: "5", Correct: true},
+			}),
+		))
+		assert.NoError(t, err)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestStore_FailChoiceValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		choices []domain.Choice
+		message string
+	}{
+		{"too few", []domain.Choice{{Label: "A", Text: "Paris", Correct: true}}, "A choice question needs at least 2 choices"},
+		{"none correct", []domain.Choice{{Label: "A", Text: "Paris"}, {Label: "B", Text: "Rome"}}, "At least one choice must be correct"},
+		{"duplicate label", []domain.Choice{{Label: "A", Text: "Paris", Correct: true}, {Label: "a", Text: "Rome"}}, "Choice a is given twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQuestionRepo := new(mocks.QuestionRepository)
+			u := NewQuestionUsecase(mockQuestionRepo)
+			err := u.Store(builder.NewQuestion(
+				builder.SetNumber("1"),
+				builder.SetQuestion("Capital of France?"),
+				builder.SetAnswerType(domain.AnswerTypeChoice),
+				builder.SetChoices(tt.choices),
+			))
+			assert.Error(t, err)
+			assert.Equal(t, tt.message, err.Error())
+			mockQuestionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnswerQuestion_ChoiceAnswer(t *testing.T) {
+	single := builder.NewQuestion(
+		builder.SetNumber("1"),
+		builder.SetQuestion("Capital of France?"),
+		builder.SetAnswer("B"),
+		builder.SetAnswerType(domain.AnswerTypeChoice),
+		builder.SetChoices([]domain.Choice{
+			{Label: "A", Text: "Rome"},
+			{Label: "B", Text: "Paris", Correct: true},
+			{Label: "C", Text: "Paris, Texas"},
+		}),
+	)
+	multiple := builder.NewQuestion(
+		builder.SetNumber("2"),
+		builder.SetQuestion("Which of these are prime?"),
+		builder.SetAnswer("A,C"),
+		builder.SetAnswerType(domain.AnswerTypeChoice),
+		builder.SetChoices([]domain.Choice{
+			{Label: "A", Text: "two", Correct: true},
+			{Label: "B", Text: "four"},
+			{Label: "C", Text: "five", Correct: true},
+		}),
+	)
+	tests := []struct {
+		name     string
+		question *domain.Question
+		answer   string
+		outcome  string
+		score    float64
+	}{
+		{"letter", single, "B", domain.OutcomeCorrect, 1},
+		{"lower case letter", single, "b", domain.OutcomeCorrect, 1},
+		{"option text", single, "paris", domain.OutcomeCorrect, 1},
+		{"option text with comma", single, "Paris, Texas", domain.OutcomeIncorrect, 0},
+		{"wrong letter", single, "A", domain.OutcomeIncorrect, 0},
+		{"extra option", single, "A,B", domain.OutcomeIncorrect, 0},
+		{"unknown option", single, "D", domain.OutcomeIncorrect, 0},
+		{"all correct letters", multiple, "C, A", domain.OutcomeCorrect, 1},
+		{"letters and text", multiple, "Two,c", domain.OutcomeCorrect, 1},
+		{"only some correct", multiple, "A", domain.OutcomeIncorrect, 0},
+		{"with a wrong one", multiple, "A,B,C", domain.OutcomeIncorrect, 0},
+		{"unknown and correct", multiple, "A,C,seven", domain.OutcomeIncorrect, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQuestionRepo := new(mocks.QuestionRepository)
+			mockQuestionRepo.On("GetByNumber", tt.question.Number).Return(*tt.question, nil).Once()
+			u := NewQuestionUsecase(mockQuestionRepo)
+			result, err := u.AnswerQuestion(tt.question.Number, tt.answer)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.outcome, result.Outcome)
+			assert.InDelta(t, tt.score, result.Score, 1e-9)
+			mockQuestionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdate_SuccessChangeCorrectChoice(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("success", func(t *testing.T) {
+		mockQuestion := builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("Capital of France?"),
+			builder.SetAnswer("A"),
+			builder.SetAnswerType(domain.AnswerTypeChoice),
+			builder.SetChoices([]domain.Choice{
+				{Label: "A", Text: "Rome", Correct: true},
+				{Label: "B", Text: "Paris"},
+			}),
+		)
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		mockQuestionRepo.On("Update", mock.MatchedBy(func(q *domain.Question) bool {
+			return q.Answer == "B" && !q.Choices[0].Correct && q.Choices[1].Correct
+		})).Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		changes, err := u.Update("1", builder.NewRequestUpdate(builder.UpdateWithAnswer("b")))
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.QuestionChange{{Field: "answer", Old: "A", New: "B"}}, changes)
+		assert.True(t, mockQuestion.Choices[0].Correct)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestAnswerQuestion_Aliases(t *testing.T) {
+	mockQuestion := builder.NewQuestion(
+		builder.SetNumber("1"),
+		builder.SetQuestion("Which country has 50 states?"),
+		builder.SetAnswer("USA"),
+		builder.SetAnswerType(domain.AnswerTypeText),
+	)
+	mockQuestion.Aliases = []string{"United States", "United States of America"}
+	tests := []struct {
+		name   string
+		answer string
+		result domain.AnswerResult
+	}{
+		{"answer", "usa", domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "USA", Feedback: "Correct!", Lang: "en"}},
+		{"alias", "united states", domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "United States", Alias: true, Feedback: "Correct!", Lang: "en"}},
+		{"longer alias", "United States of America!", domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "United States of America", Alias: true, Feedback: "Correct!", Lang: "en"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQuestionRepo := new(mocks.QuestionRepository)
+			mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+			u := NewQuestionUsecase(mockQuestionRepo)
+			result, err := u.AnswerQuestion("1", tt.answer)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.result, result)
+			mockQuestionRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		result, err := u.AnswerQuestion("1", "Canada")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.OutcomeIncorrect, result.Outcome)
+		assert.Empty(t, result.Accepted)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestAddAlias(t *testing.T) {
+	mockQuestion := builder.NewQuestion(
+		builder.SetNumber("1"),
+		builder.SetQuestion("Which country has 50 states?"),
+		builder.SetAnswer("USA"),
+		builder.SetAnswerType(domain.AnswerTypeText),
+	)
+	mockQuestion.ID = 3
+	mockQuestion.Aliases = []string{"United States"}
+
+	t.Run("success", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		mockQuestionRepo.On("AddAlias", 3, "America").Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		assert.NoError(t, u.AddAlias("1", " America "))
+		mockQuestionRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		err := u.AddAlias("1", "united states")
+		assert.Error(t, err)
+		assert.Equal(t, "Question no 1 already accepts United States", err.Error())
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestRemoveAlias(t *testing.T) {
+	mockQuestion := builder.NewQuestion(
+		builder.SetNumber("1"),
+		builder.SetQuestion("Which country has 50 states?"),
+		builder.SetAnswer("USA"),
+		builder.SetAnswerType(domain.AnswerTypeText),
+	)
+	mockQuestion.ID = 3
+	mockQuestion.Aliases = []string{"United States"}
+
+	t.Run("success", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		mockQuestionRepo.On("RemoveAlias", 3, "United States").Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		assert.NoError(t, u.RemoveAlias("1", "United States"))
+		mockQuestionRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		err := u.RemoveAlias("1", "America")
+		assert.Error(t, err)
+		assert.Equal(t, "Question no 1 has no alias America", err.Error())
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestAnswerQuestion_NumericAnswer(t *testing.T) {
+	tests := []struct {
+		stored  string
+		answer  string
+		correct bool
+	}{
+		{"21", "twenty-one", true},
+		{"21", "twenty one", true},
+		{"-5", "minus five", true},
+		{"1000", "1,000", true},
+		{"1,000", "one thousand", true},
+		{"3.5", "3.50", true},
+		{"0.75", "3/4", true},
+		{"0.75", "three quarters", true},
+		{"102", "one hundred and two", true},
+		{"10000000000000000000", "ten quintillion", true},
+		{"21", "twenty", false},
+		{"5", "five five", false},
+		{"3.5", "3", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.stored+" "+tt.answer, func(t *testing.T) {
+			mockQuestion := builder.NewQuestion(
+				builder.SetNumber("1"),
+				builder.SetQuestion("lorem ipsum?"),
+				builder.SetAnswer(tt.stored),
+				builder.SetAnswerType(domain.AnswerTypeNumeric),
+			)
+			mockQuestionRepo := new(mocks.QuestionRepository)
+			mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+			u := NewQuestionUsecase(mockQuestionRepo)
+			result, err := u.AnswerQuestion("1", tt.answer)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.correct, result.Outcome == domain.OutcomeCorrect)
+			mockQuestionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnswerQuestion_Languages(t *testing.T) {
+	tests := []struct {
+		name    string
+		langs   []string
+		lang    string
+		answer  string
+		correct bool
+		message string
+	}{
+		{"installation language", []string{"id"}, "", "dua puluh satu", true, ""},
+		{"installation languages", []string{"en", "id"}, "", "twenty-one", true, ""},
+		{"digits", []string{"id"}, "", "21", true, ""},
+		{"english words not accepted", []string{"id"}, "", "twenty-one", false, "Jawaban Salah!"},
+		{"question language wins", []string{"en"}, "es", "veintiuno", true, ""},
+		{"question language only", []string{"en"}, "de", "twenty-one", false, "Falsche Antwort!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQuestion := builder.NewQuestion(
+				builder.SetNumber("1"),
+				builder.SetQuestion("lorem ipsum?"),
+				builder.SetAnswer("21"),
+				builder.SetAnswerType(domain.AnswerTypeNumeric),
+				builder.SetLang(tt.lang),
+			)
+			mockQuestionRepo := new(mocks.QuestionRepository)
+			mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+			u := NewQuestionUsecase(mockQuestionRepo, WithLanguages(tt.langs))
+			result, err := u.AnswerQuestion("1", tt.answer)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.correct, result.Outcome == domain.OutcomeCorrect)
+			assert.NotEmpty(t, result.Lang)
+			if !tt.correct {
+				assert.Equal(t, tt.message, result.Feedback)
+			}
+			mockQuestionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStore_LanguageValidation(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		mockQuestionRepo.On("Store", mock.AnythingOfType("*domain.Question")).Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		err := u.Store(builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("lorem ipsum?"),
+			builder.SetAnswer("dua puluh satu"),
+			builder.SetLang("id"),
+		))
+		assert.NoError(t, err)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		u := NewQuestionUsecase(mockQuestionRepo)
+		err := u.Store(builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("lorem ipsum?"),
+			builder.SetAnswer("21"),
+			builder.SetLang("xx"),
+		))
+		assert.Error(t, err)
+		assert.Equal(t, "Lang must be one of en, de, es, fr, id", err.Error())
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestAnswerQuestion_Grading(t *testing.T) {
+	tests := []struct {
+		name    string
+		grading domain.Grading
+		answer  string
+		correct bool
+	}{
+		{"exact", domain.Grading{}, "3.14", false},
+		{"absolute inside", domain.Grading{Rule: domain.GradingAbsolute, Tolerance: "0.01"}, "3.15", true},
+		{"absolute edge", domain.Grading{Rule: domain.GradingAbsolute, Tolerance: "0.01"}, "3.13159", true},
+		{"absolute outside", domain.Grading{Rule: domain.GradingAbsolute, Tolerance: "0.01"}, "3.152", false},
+		{"relative inside", domain.Grading{Rule: domain.GradingRelative, Tolerance: "10"}, "three point four", true},
+		{"relative outside", domain.Grading{Rule: domain.GradingRelative, Tolerance: "1"}, "3.2", false},
+		{"range inside", domain.Grading{Rule: domain.GradingRange, Min: "3", Max: "7/2"}, "3.5", true},
+		{"range outside", domain.Grading{Rule: domain.GradingRange, Min: "3", Max: "7/2"}, "2.99", false},
+		{"not a number", domain.Grading{Rule: domain.GradingAbsolute, Tolerance: "1"}, "pi", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQuestion := builder.NewQuestion(
+				builder.SetNumber("1"),
+				builder.SetQuestion("Value of pi?"),
+				builder.SetAnswer("3.14159"),
+				builder.SetGrading(tt.grading),
+			)
+			mockQuestionRepo := new(mocks.QuestionRepository)
+			mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+			u := NewQuestionUsecase(mockQuestionRepo)
+			result, err := u.AnswerQuestion("1", tt.answer)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.correct, result.Outcome == domain.OutcomeCorrect)
+			mockQuestionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStore_GradingValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		answerType string
+		grading    domain.Grading
+		err        string
+	}{
+		{"text question", domain.AnswerTypeText, domain.Grading{Rule: domain.GradingAbsolute, Tolerance: "1"}, "Only numeric questions can have a grading rule"},
+		{"unknown rule", domain.AnswerTypeNumeric, domain.Grading{Rule: "fuzzy"}, "Grading rule must be one of absolute, relative or range"},
+		{"invalid tolerance", domain.AnswerTypeNumeric, domain.Grading{Rule: domain.GradingAbsolute, Tolerance: "some"}, "Tolerance must be a valid numeric value"},
+		{"negative tolerance", domain.AnswerTypeNumeric, domain.Grading{Rule: domain.GradingRelative, Tolerance: "-5"}, "Tolerance must not be negative"},
+		{"reversed range", domain.AnswerTypeNumeric, domain.Grading{Rule: domain.GradingRange, Min: "30", Max: "10"}, "Range minimum must not be greater than its maximum"},
+		{"answer outside range", domain.AnswerTypeNumeric, domain.Grading{Rule: domain.GradingRange, Min: "1", Max: "10"}, "Answer must lie within the range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQuestionRepo := new(mocks.QuestionRepository)
+			u := NewQuestionUsecase(mockQuestionRepo)
+			err := u.Store(builder.NewQuestion(
+				builder.SetNumber("1"),
+				builder.SetQuestion("lorem ipsum?"),
+				builder.SetAnswer("21"),
+				builder.SetAnswerType(tt.answerType),
+				builder.SetGrading(tt.grading),
+			))
+			assert.Error(t, err)
+			assert.Equal(t, tt.err, err.Error())
+			mockQuestionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdate_SuccessChangeGrading(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("success", func(t *testing.T) {
+		mockQuestion := builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("Boiling point of water in F?"),
+			builder.SetAnswer("212"),
+			builder.SetAnswerType(domain.AnswerTypeNumeric),
+		)
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		mockQuestionRepo.On("Update", mock.MatchedBy(func(q *domain.Question) bool {
+			return q.Grading == domain.Grading{Rule: domain.GradingRange, Min: "210", Max: "214"}
+		})).Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		changes, err := u.Update("1", builder.NewRequestUpdate(builder.UpdateWithGrading(dto.RequestGrading{Rule: domain.GradingRange, Min: "210", Max: "214"})))
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.QuestionChange{{Field: "grading", Old: "exact", New: "between 210 and 214"}}, changes)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestAnswerQuestion_Fuzzy(t *testing.T) {
+	tests := []struct {
+		name   string
+		fuzzy  int
+		accept bool
+		answer string
+		result domain.AnswerResult
+	}{
+		{"exact", 2, true, "paris", domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "Paris", Feedback: "Correct!", Lang: "en"}},
+		{"missing letter", 2, true, "Pari", domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "Paris", Distance: 1, Feedback: "Close, but accepted!", Lang: "en"}},
+		{"double letter", 2, true, "Parris", domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "Paris", Distance: 1, Feedback: "Close, but accepted!", Lang: "en"}},
+		{"swapped letters", 1, true, "Pairs", domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "Paris", Distance: 1, Feedback: "Close, but accepted!", Lang: "en"}},
+		{"close to alias", 1, true, "Lutetia", domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "Lutetia", Alias: true, Feedback: "Correct!", Lang: "en"}},
+		{"typo in alias", 1, true, "Lutecia", domain.AnswerResult{Outcome: domain.OutcomeCorrect, Score: 1, Accepted: "Lutetia", Alias: true, Distance: 1, Feedback: "Close, but accepted!", Lang: "en"}},
+		{"too many typos", 1, true, "Parisss", domain.AnswerResult{Outcome: domain.OutcomeIncorrect, Feedback: "Wrong Answer!", Lang: "en"}},
+		{"fuzzy off", 0, true, "Pari", domain.AnswerResult{Outcome: domain.OutcomeIncorrect, Feedback: "Wrong Answer!", Lang: "en"}},
+		{"near miss rejected", 2, false, "Pari", domain.AnswerResult{Outcome: domain.OutcomeIncorrect, Distance: 1, Feedback: "Almost — check your spelling!", Lang: "en"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQuestion := builder.NewQuestion(
+				builder.SetNumber("1"),
+				builder.SetQuestion("Capital of France?"),
+				builder.SetAnswer("Paris"),
+				builder.SetAnswerType(domain.AnswerTypeText),
+				builder.SetFuzzy(tt.fuzzy),
+			)
+			mockQuestion.Aliases = []string{"Lutetia"}
+			mockQuestionRepo := new(mocks.QuestionRepository)
+			mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+			u := NewQuestionUsecase(mockQuestionRepo, WithFuzzyAccept(tt.accept))
+			result, err := u.AnswerQuestion("1", tt.answer)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.result, result)
+			mockQuestionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStore_FuzzyValidation(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	u := NewQuestionUsecase(mockQuestionRepo)
+	err := u.Store(builder.NewQuestion(
+		builder.SetNumber("1"),
+		builder.SetQuestion("lorem ipsum?"),
+		builder.SetAnswer("21"),
+		builder.SetFuzzy(2),
+	))
+	assert.Error(t, err)
+	assert.Equal(t, "Only text questions can have fuzzy matching", err.Error())
+	mockQuestionRepo.AssertExpectations(t)
+}
+
+func TestUpdate_SuccessChangeTimeLimit(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("success", func(t *testing.T) {
+		mockQuestion := builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("lorem ipsum dolor?"),
+			builder.SetAnswer("2"),
+			builder.SetAnswerType(domain.AnswerTypeNumeric),
+			builder.SetTimeLimit(time.Minute),
+		)
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		mockQuestionRepo.On("Update", mock.MatchedBy(func(q *domain.Question) bool {
+			return q.TimeLimit == 0
+		})).Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		changes, err := u.Update("1", builder.NewRequestUpdate(builder.UpdateWithTimeLimit(0)))
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.QuestionChange{{Field: "time_limit", Old: "1m0s", New: "none"}}, changes)
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestStore_NegativeTimeLimit(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	u := NewQuestionUsecase(mockQuestionRepo)
+	err := u.Store(builder.NewQuestion(
+		builder.SetNumber("1"),
+		builder.SetQuestion("lorem ipsum?"),
+		builder.SetAnswer("21"),
+		builder.SetTimeLimit(-time.Second),
+	))
+	assert.Error(t, err)
+	assert.Equal(t, "TimeLimit must be 0 or greater", err.Error())
+	mockQuestionRepo.AssertExpectations(t)
+}
+
+func TestUpdate_SuccessChangeHintsAndExplanation(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	t.Run("success", func(t *testing.T) {
+		mockQuestion := builder.NewQuestion(
+			builder.SetNumber("1"),
+			builder.SetQuestion("Capital of France?"),
+			builder.SetAnswer("Paris"),
+			builder.SetAnswerType(domain.AnswerTypeText),
+			builder.SetHints("It's in Europe"),
+		)
+		mockQuestionRepo.On("GetByNumber", "1").Return(*mockQuestion, nil).Once()
+		mockQuestionRepo.On("Update", mock.MatchedBy(func(q *domain.Question) bool {
+			return len(q.Hints) == 2 && q.Explanation == "Since 987."
+		})).Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		changes, err := u.Update("1", builder.NewRequestUpdate(
+			builder.UpdateWithHints([]string{"It's in Europe", "It starts with P"}),
+			builder.UpdateWithExplanation("Since 987."),
+		))
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.QuestionChange{
+			{Field: "hints", Old: "It's in Europe", New: "It's in Europe | It starts with P"},
+			{Field: "explanation", Old: "", New: "Since 987."},
+		}, changes)
+
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetAll_NormalizesTags(t *testing.T) {
+	mockQuestionRepo := new(mocks.QuestionRepository)
+	mockQuestionRepo.On("GetAll", domain.QuestionListOptions{Filter: domain.QuestionFilter{Tags: []string{"geography"}}}).Return([]*domain.Question{}, nil).Once()
+	u := NewQuestionUsecase(mockQuestionRepo)
+	_, err := u.GetAll(domain.QuestionFilter{Tags: []string{" Geography", "geography"}})
+	assert.NoError(t, err)
+	mockQuestionRepo.AssertExpectations(t)
+}
+
+func TestTagQuestion(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		mockQuestionRepo.On("GetByNumber", "1").Return(domain.Question{ID: 5, Number: "1", Tags: []string{"europe"}}, nil).Once()
+		mockQuestionRepo.On("AddTags", 5, []string{"capitals"}).Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		added, err := u.TagQuestion("1", []string{"Europe", "Capitals "})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"capitals"}, added)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		u := NewQuestionUsecase(mockQuestionRepo)
+		_, err := u.TagQuestion("1", []string{" "})
+		assert.Error(t, err)
+		assert.Equal(t, "Tag is required", err.Error())
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestUntagQuestion(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		mockQuestionRepo.On("GetByNumber", "1").Return(domain.Question{ID: 5, Number: "1", Tags: []string{"europe"}}, nil).Once()
+		mockQuestionRepo.On("RemoveTags", 5, []string{"europe"}).Return(nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		assert.NoError(t, u.UntagQuestion("1", []string{"Europe"}))
+		mockQuestionRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		mockQuestionRepo.On("GetByNumber", "1").Return(domain.Question{ID: 5, Number: "1", Tags: []string{"europe"}}, nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		err := u.UntagQuestion("1", []string{"asia"})
+		assert.Error(t, err)
+		assert.Equal(t, "Question no 1 has no tag asia", err.Error())
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		results := []domain.SearchResult{{Question: domain.Question{Number: "1"}, Score: 2, Snippet: "Capital of **France**?"}}
+		mockQuestionRepo.On("Search", domain.SearchQuery{Terms: "france", Limit: 10}).Return(results, nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		found, err := u.Search(domain.SearchQuery{Terms: " france ", Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, results, found)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		u := NewQuestionUsecase(mockQuestionRepo)
+		_, err := u.Search(domain.SearchQuery{Terms: "  "})
+		assert.Error(t, err)
+		assert.Equal(t, "Search terms are required", err.Error())
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}
+
+func TestList(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		questions := []*domain.Question{{Number: "11"}}
+		options := domain.QuestionListOptions{Filter: domain.QuestionFilter{Tags: []string{"geography"}}, Sort: domain.SortQuestion, Limit: 10, Offset: 10}
+		mockQuestionRepo.On("GetAll", options).Return(questions, nil).Once()
+		mockQuestionRepo.On("Count", options.Filter).Return(11, nil).Once()
+		u := NewQuestionUsecase(mockQuestionRepo)
+		options.Filter.Tags = []string{"Geography "}
+		page, err := u.List(options)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.QuestionPage{Questions: questions, Offset: 10, Total: 11}, page)
+		mockQuestionRepo.AssertExpectations(t)
+	})
+
+	t.Run("error-failed", func(t *testing.T) {
+		mockQuestionRepo := new(mocks.QuestionRepository)
+		u := NewQuestionUsecase(mockQuestionRepo)
+		_, err := u.List(domain.QuestionListOptions{Sort: "answer"})
+		assert.Error(t, err)
+		assert.Equal(t, "Sort must be one of number, created or question", err.Error())
+		mockQuestionRepo.AssertExpectations(t)
+	})
+}